@@ -0,0 +1,194 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"math/big"
+	"strconv"
+)
+
+// NullBigInt represents an arbitrary-precision integer stored in a NUMERIC(38,0) or DECIMAL column that may be null.
+//
+// It is encoded as a decimal string in JSON and text, so values above 2^53 survive JavaScript clients
+// and travel through protobuf string fields. Null is encoded as JSON null and as an empty string in text.
+// A valid NullBigInt with a nil Int holds zero, like the zero big.Int.
+type NullBigInt struct {
+	Int   *big.Int
+	Valid bool // Valid is true if Int is not NULL
+}
+
+// ParseNullBigInt parses the decimal representation of an integer.
+// The empty string, the default of a protobuf string field, is parsed as null.
+// Invalid input returns a *ConversionError wrapping ErrInvalidFormat.
+func ParseNullBigInt(in string) (NullBigInt, error) {
+	var n NullBigInt
+
+	err := n.UnmarshalText([]byte(in))
+
+	return n, err
+}
+
+// String returns the decimal representation of the integer, or the empty string if it is null.
+func (n NullBigInt) String() string {
+	if !n.Valid {
+		return ""
+	}
+
+	return n.integer().String()
+}
+
+// integer returns the value of the valid NullBigInt, treating a nil Int as zero.
+func (n NullBigInt) integer() *big.Int {
+	if n.Int == nil {
+		return new(big.Int)
+	}
+
+	return n.Int
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts integers as well as the textual output of NUMERIC columns, e.g. "42" or "42.000".
+// Values with a fractional part return a *ConversionError wrapping ErrInexact.
+func (n *NullBigInt) Scan(value any) error {
+	var (
+		r   *big.Rat
+		err error
+	)
+
+	switch v := value.(type) {
+	case nil:
+		*n = NullBigInt{}
+
+		return nil
+	case int64:
+		*n = NullBigInt{Int: big.NewInt(v), Valid: true}
+
+		return nil
+	case float64:
+		r, err = parseDecimal(strconv.FormatFloat(v, 'g', -1, 64))
+	case []byte:
+		r, err = parseDecimal(string(v))
+	case string:
+		r, err = parseDecimal(v)
+	default:
+		err = conversionError(value, "NullBigInt", ErrUnsupported, nil)
+	}
+
+	if err == nil && !r.IsInt() {
+		err = conversionError(value, "NullBigInt", ErrInexact, nil)
+	}
+
+	if err != nil {
+		*n = NullBigInt{}
+
+		return err
+	}
+
+	*n = NullBigInt{Int: new(big.Int).Set(r.Num()), Valid: true}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// Values outside the int64 range are returned as a decimal string.
+func (n NullBigInt) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if n.integer().IsInt64() {
+		return n.integer().Int64(), nil
+	}
+
+	return n.integer().String(), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (n NullBigInt) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (n *NullBigInt) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullBigInt{}
+
+		return nil
+	}
+
+	i, ok := new(big.Int).SetString(string(text), 10)
+	if !ok {
+		*n = NullBigInt{}
+
+		return conversionError(string(text), "*big.Int", ErrInvalidFormat, nil)
+	}
+
+	*n = NullBigInt{Int: i, Valid: true}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The integer is encoded as a quoted string.
+func (n NullBigInt) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return strconv.AppendQuote(nil, n.integer().String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both quoted strings and plain numbers are accepted.
+func (n *NullBigInt) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = NullBigInt{}
+
+		return nil
+	}
+
+	return n.UnmarshalText(unquote(data))
+}
+
+// NullBigInt converts the *big.Int to a NullBigInt type, validating it against the column type.
+// A nil pointer is converted to null.
+func (s NumericSpec) NullBigInt(i *big.Int) (NullBigInt, error) {
+	if i == nil {
+		return NullBigInt{}, nil
+	}
+
+	if err := s.Check(new(big.Rat).SetInt(i)); err != nil {
+		return NullBigInt{}, err
+	}
+
+	return NullBigIntOf(i), nil
+}
+
+// NullBigIntOf converts the *big.Int to a NullBigInt type.
+// A nil pointer is converted to null. The value is copied, so later changes to it do not affect the result.
+// Use a NumericSpec to validate the value against the column type.
+func NullBigIntOf(i *big.Int) NullBigInt {
+	if i == nil {
+		return NullBigInt{}
+	}
+
+	return NullBigInt{Int: new(big.Int).Set(i), Valid: true}
+}
+
+// UnwrapBigInt unwraps the NullBigInt to a *big.Int.
+// If the value is null the function will return nil.
+// The value is copied, so changes to the result do not affect the NullBigInt.
+func UnwrapBigInt(n NullBigInt) *big.Int {
+	if !n.Valid {
+		return nil
+	}
+
+	return new(big.Int).Set(n.integer())
+}
@@ -0,0 +1,239 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+const maxNumeric38 = "99999999999999999999999999999999999999"
+
+func TestNullBigIntScan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    any
+		expected string
+		err      error
+	}{
+		{name: "should scan NUMERIC(38,0) text", input: []byte(maxNumeric38), expected: maxNumeric38},
+		{name: "should scan negative NUMERIC string", input: "-" + maxNumeric38, expected: "-" + maxNumeric38},
+		{name: "should scan integral NUMERIC text with trailing zeros", input: []byte("42.000"), expected: "42"},
+		{name: "should scan integer", input: int64(-42), expected: "-42"},
+		{name: "should scan integral float", input: 1e20, expected: "100000000000000000000"},
+		{name: "should fail to scan fractional NUMERIC text", input: []byte("42.5"), err: ErrInexact},
+		{name: "should fail to scan fractional float", input: 0.5, err: ErrInexact},
+		{name: "should fail to scan NaN", input: "NaN", err: ErrInvalidFormat},
+		{name: "should fail to scan unsupported type", input: true, err: ErrUnsupported},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := NullBigInt{Int: big.NewInt(7), Valid: true}
+
+			err := n.Scan(tc.input)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("error mismatch; got '%v', expected: '%v'", err, tc.err)
+				}
+
+				var convErr *ConversionError
+				if !errors.As(err, &convErr) {
+					t.Fatalf("error should be a ConversionError, got: '%v'", err)
+				}
+
+				if n.Valid {
+					t.Fatalf("result should not be valid on error, got: '%v'", n)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if !n.Valid || n.String() != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%s'", n, tc.expected)
+			}
+		})
+	}
+
+	t.Run("successfully scan NULL", func(t *testing.T) {
+		n := NullBigInt{Int: big.NewInt(7), Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid || n.Int != nil {
+			t.Fatalf("result should be null, got: '%v' (%v)", n, err)
+		}
+	})
+}
+
+func TestNullBigIntValue(t *testing.T) {
+	large, _ := new(big.Int).SetString(maxNumeric38, 10)
+
+	testCases := []struct {
+		name     string
+		input    NullBigInt
+		expected driver.Value
+	}{
+		{name: "should write null", input: NullBigInt{}, expected: nil},
+		{name: "should write int64", input: NullBigIntOf(big.NewInt(-42)), expected: int64(-42)},
+		{name: "should write values outside int64 as string", input: NullBigIntOf(large), expected: maxNumeric38},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.input.Value()
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNullBigIntJSON(t *testing.T) {
+	type entry struct {
+		Amount NullBigInt `json:"amount"`
+	}
+
+	t.Run("should encode as quoted string", func(t *testing.T) {
+		in, _ := ParseNullBigInt(maxNumeric38)
+
+		data, err := json.Marshal(entry{Amount: in})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		expected := `{"amount":"` + maxNumeric38 + `"}`
+		if string(data) != expected {
+			t.Fatalf("result mismatch got '%s', expected: '%s'", data, expected)
+		}
+	})
+
+	t.Run("should encode null as null", func(t *testing.T) {
+		data, err := json.Marshal(entry{})
+		if err != nil || string(data) != `{"amount":null}` {
+			t.Fatalf("result mismatch got '%s' (%v)", data, err)
+		}
+	})
+
+	t.Run("should decode quoted strings, numbers and null", func(t *testing.T) {
+		for input, expected := range map[string]string{
+			`{"amount":"-` + maxNumeric38 + `"}`: "-" + maxNumeric38,
+			`{"amount":` + maxNumeric38 + `}`:    maxNumeric38,
+			`{"amount":null}`:                    "",
+		} {
+			e := entry{Amount: NullBigIntOf(big.NewInt(7))}
+
+			if err := json.Unmarshal([]byte(input), &e); err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if e.Amount.String() != expected {
+				t.Fatalf("result mismatch got '%v', expected: '%s'", e.Amount, expected)
+			}
+		}
+	})
+
+	t.Run("should fail to decode fractional numbers", func(t *testing.T) {
+		var e entry
+
+		if err := json.Unmarshal([]byte(`{"amount":"1.5"}`), &e); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+		}
+	})
+}
+
+func TestParseNullBigInt(t *testing.T) {
+	t.Run("should parse empty proto string as null", func(t *testing.T) {
+		result, err := ParseNullBigInt("")
+		if err != nil || result.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", result, err)
+		}
+	})
+
+	t.Run("should round trip decimal string", func(t *testing.T) {
+		result, err := ParseNullBigInt("-" + maxNumeric38)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result.String() != "-"+maxNumeric38 {
+			t.Fatalf("round trip mismatch got '%s', expected: '-%s'", result, maxNumeric38)
+		}
+	})
+
+	t.Run("should fail to parse random string", func(t *testing.T) {
+		if _, err := ParseNullBigInt("foobar"); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+		}
+	})
+}
+
+func TestNumericSpecNullBigInt(t *testing.T) {
+	spec := NumericSpec{Precision: 38}
+
+	limit, _ := new(big.Int).SetString(maxNumeric38, 10)
+
+	if result, err := spec.NullBigInt(limit); err != nil || result.String() != maxNumeric38 {
+		t.Fatalf("result mismatch got '%v' (%v), expected: '%s'", result, err, maxNumeric38)
+	}
+
+	if _, err := spec.NullBigInt(new(big.Int).Add(limit, big.NewInt(1))); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+	}
+
+	if result, err := spec.NullBigInt(nil); err != nil || result.Valid {
+		t.Fatalf("result should be null without error, got: '%v', '%v'", result, err)
+	}
+}
+
+func TestNullBigIntNilInt(t *testing.T) {
+	n := NullBigInt{Valid: true}
+
+	if v, err := n.Value(); err != nil || v != int64(0) {
+		t.Fatalf("result mismatch got '%v' (%v), expected: '0'", v, err)
+	}
+
+	if data, err := n.MarshalJSON(); err != nil || string(data) != `"0"` {
+		t.Fatalf("result mismatch got '%s' (%v), expected: '\"0\"'", data, err)
+	}
+
+	if result := UnwrapBigInt(n); result == nil || result.Sign() != 0 {
+		t.Fatalf("result should be zero, got: '%v'", result)
+	}
+}
+
+func TestUnwrapBigInt(t *testing.T) {
+	in := big.NewInt(42)
+	n := NullBigIntOf(in)
+
+	in.SetInt64(7)
+
+	result := UnwrapBigInt(n)
+	if result == nil || result.Int64() != 42 {
+		t.Fatalf("result mismatch got '%v', expected: '%d'", result, 42)
+	}
+
+	result.SetInt64(7)
+
+	if n.Int.Int64() != 42 {
+		t.Fatalf("unwrapped value should be a copy, got: '%v'", n)
+	}
+
+	if result := UnwrapBigInt(NullBigInt{}); result != nil {
+		t.Fatalf("result should be null, got: '%v'", result)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// NullBytes represents the content of a BYTEA or BLOB column that may be null.
+// Unlike a plain []byte, where both null and empty are commonly represented by a nil slice,
+// an empty value is Valid with a zero length Bytes and is written as an empty value rather than null.
+//
+// In JSON the content is encoded as a standard base64 string, matching encoding/json and protojson.
+// Use NullHexBytes for hexadecimal strings.
+//
+// Some drivers scan empty BLOBs as null, which cannot be told apart once scanned.
+type NullBytes struct {
+	Bytes []byte
+	Valid bool // Valid is true if Bytes is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+// The bytes are copied, as the driver may reuse its buffer on the next call to Next.
+func (n *NullBytes) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*n = NullBytes{}
+	case []byte:
+		*n = NullBytes{Bytes: bytes.Clone(v), Valid: true}
+
+		// bytes.Clone returns nil for an empty slice.
+		if n.Bytes == nil {
+			n.Bytes = []byte{}
+		}
+	case string:
+		*n = NullBytes{Bytes: []byte(v), Valid: true}
+	default:
+		*n = NullBytes{}
+
+		return conversionError(value, "NullBytes", ErrUnsupported, nil)
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// A valid value is never written as null, even if Bytes is nil.
+func (n NullBytes) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if n.Bytes == nil {
+		return []byte{}, nil
+	}
+
+	return n.Bytes, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullBytes) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(base64.StdEncoding.EncodeToString(n.Bytes))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Invalid base64 returns a *ConversionError wrapping ErrInvalidFormat.
+func (n *NullBytes) UnmarshalJSON(data []byte) error {
+	return n.unmarshalJSON(data, base64.StdEncoding.DecodeString)
+}
+
+// unmarshalJSON decodes the JSON string using the decode function.
+func (n *NullBytes) unmarshalJSON(data []byte, decode func(string) ([]byte, error)) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = NullBytes{}
+
+		return nil
+	}
+
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		*n = NullBytes{}
+
+		return conversionError(string(data), "NullBytes", ErrInvalidFormat, err)
+	}
+
+	b, err := decode(s)
+	if err != nil {
+		*n = NullBytes{}
+
+		return conversionError(s, "NullBytes", ErrInvalidFormat, err)
+	}
+
+	*n = NullBytes{Bytes: b, Valid: true}
+
+	return nil
+}
+
+// NullHexBytes is a NullBytes encoded as a lowercase hexadecimal string in JSON, e.g. "deadbeef".
+// It is scanned and written like NullBytes.
+type NullHexBytes struct {
+	NullBytes
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullHexBytes) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(hex.EncodeToString(n.Bytes))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Invalid hexadecimal returns a *ConversionError wrapping ErrInvalidFormat.
+func (n *NullHexBytes) UnmarshalJSON(data []byte) error {
+	return n.unmarshalJSON(data, hex.DecodeString)
+}
+
+// NullBytesOf converts a byte slice, or a pointer to one, to a NullBytes type.
+// A nil slice or nil pointer is converted to null, while an empty slice or a pointer to a nil slice is converted to an empty value.
+func NullBytesOf[T []byte | *[]byte](b T) NullBytes {
+	switch v := any(b).(type) {
+	case []byte:
+		if v == nil {
+			return NullBytes{}
+		}
+
+		return NullBytes{Bytes: v, Valid: true}
+	case *[]byte:
+		if v == nil {
+			return NullBytes{}
+		}
+
+		if *v == nil {
+			return NullBytes{Bytes: []byte{}, Valid: true}
+		}
+
+		return NullBytes{Bytes: *v, Valid: true}
+	}
+
+	return NullBytes{}
+}
+
+// UnwrapBytes unwraps the NullBytes to a byte slice.
+// If the value is null the function will return nil, an empty value is returned as a non-nil empty slice.
+func UnwrapBytes(n NullBytes) []byte {
+	if !n.Valid {
+		return nil
+	}
+
+	if n.Bytes == nil {
+		return []byte{}
+	}
+
+	return n.Bytes
+}
+
+// UnwrapBytesPtr unwraps the NullBytes to a pointer to a byte slice.
+// If the value is null the function will return nil.
+func UnwrapBytesPtr(n NullBytes) *[]byte {
+	if !n.Valid {
+		return nil
+	}
+
+	b := UnwrapBytes(n)
+
+	return &b
+}
@@ -0,0 +1,175 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNullBytesScan(t *testing.T) {
+	t.Run("successfully scan and copy bytes", func(t *testing.T) {
+		buf := []byte("attachment")
+
+		var n NullBytes
+
+		if err := n.Scan(buf); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		buf[0] = 'X'
+
+		if !n.Valid || string(n.Bytes) != "attachment" {
+			t.Fatalf("result mismatch got '%v', expected: 'attachment'", n)
+		}
+	})
+
+	t.Run("successfully scan empty bytes as empty value", func(t *testing.T) {
+		var n NullBytes
+
+		if err := n.Scan([]byte{}); err != nil || !n.Valid || n.Bytes == nil || len(n.Bytes) != 0 {
+			t.Fatalf("result should be empty and valid, got: '%#v' (%v)", n, err)
+		}
+	})
+
+	t.Run("successfully scan NULL", func(t *testing.T) {
+		n := NullBytes{Bytes: []byte("x"), Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid || n.Bytes != nil {
+			t.Fatalf("result should be null, got: '%v' (%v)", n, err)
+		}
+	})
+
+	t.Run("should fail to scan unsupported type", func(t *testing.T) {
+		var n NullBytes
+
+		if err := n.Scan(int64(1)); !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("error should wrap ErrUnsupported, got: '%v'", err)
+		}
+	})
+}
+
+func TestNullBytesValue(t *testing.T) {
+	if v, err := (NullBytes{}).Value(); v != nil || err != nil {
+		t.Fatalf("value should be nil without error, got: '%v', '%v'", v, err)
+	}
+
+	// A nil []byte is written as null by database/sql, so an empty value must not be nil.
+	v, err := (NullBytes{Valid: true}).Value()
+	if b, ok := v.([]byte); err != nil || !ok || b == nil || len(b) != 0 {
+		t.Fatalf("value should be an empty non-nil slice, got: '%#v' (%v)", v, err)
+	}
+}
+
+func TestNullBytesJSON(t *testing.T) {
+	type attachment struct {
+		Data   NullBytes    `json:"data"`
+		Digest NullHexBytes `json:"digest"`
+	}
+
+	testCases := []struct {
+		name  string
+		input attachment
+		json  string
+	}{
+		{
+			name:  "should encode content",
+			input: attachment{Data: NullBytesOf([]byte("hi")), Digest: NullHexBytes{NullBytesOf([]byte{0xde, 0xad})}},
+			json:  `{"data":"aGk=","digest":"dead"}`,
+		},
+		{
+			name:  "should encode empty content as empty string",
+			input: attachment{Data: NullBytesOf([]byte{}), Digest: NullHexBytes{NullBytesOf([]byte{})}},
+			json:  `{"data":"","digest":""}`,
+		},
+		{
+			name:  "should encode null as null",
+			input: attachment{},
+			json:  `{"data":null,"digest":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.input)
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if string(data) != tc.json {
+				t.Fatalf("result mismatch got '%s', expected: '%s'", data, tc.json)
+			}
+
+			var result attachment
+
+			if err := json.Unmarshal(data, &result); err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result.Data.Valid != tc.input.Data.Valid || !bytes.Equal(result.Data.Bytes, tc.input.Data.Bytes) ||
+				result.Digest.Valid != tc.input.Digest.Valid || !bytes.Equal(result.Digest.Bytes, tc.input.Digest.Bytes) {
+				t.Fatalf("round trip mismatch got '%+v', expected: '%+v'", result, tc.input)
+			}
+		})
+	}
+
+	t.Run("should fail to decode invalid encodings", func(t *testing.T) {
+		for _, input := range []string{`{"data":"!!"}`, `{"digest":"xyz"}`, `{"data":42}`} {
+			var result attachment
+
+			if err := json.Unmarshal([]byte(input), &result); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat for '%s', got: '%v'", input, err)
+			}
+		}
+	})
+}
+
+func TestNullBytesOf(t *testing.T) {
+	empty := []byte{}
+
+	var (
+		nilSlice []byte
+		nilPtr   *[]byte
+	)
+
+	if result := NullBytesOf(nilSlice); result.Valid {
+		t.Fatalf("nil slice should be null, got: '%v'", result)
+	}
+
+	if result := NullBytesOf(empty); !result.Valid || len(result.Bytes) != 0 {
+		t.Fatalf("empty slice should be valid, got: '%v'", result)
+	}
+
+	if result := NullBytesOf(nilPtr); result.Valid {
+		t.Fatalf("nil pointer should be null, got: '%v'", result)
+	}
+
+	if result := NullBytesOf(&nilSlice); !result.Valid || result.Bytes == nil {
+		t.Fatalf("pointer to nil slice should be an empty value, got: '%#v'", result)
+	}
+}
+
+func TestUnwrapBytes(t *testing.T) {
+	if result := UnwrapBytes(NullBytes{}); result != nil {
+		t.Fatalf("result should be nil, got: '%v'", result)
+	}
+
+	if result := UnwrapBytes(NullBytes{Valid: true}); result == nil || len(result) != 0 {
+		t.Fatalf("result should be an empty non-nil slice, got: '%#v'", result)
+	}
+
+	if result := UnwrapBytesPtr(NullBytes{}); result != nil {
+		t.Fatalf("result should be nil, got: '%v'", result)
+	}
+
+	if result := UnwrapBytesPtr(NullBytesOf([]byte("x"))); result == nil || string(*result) != "x" {
+		t.Fatalf("result mismatch got '%v', expected: 'x'", result)
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/justinsimmons/sqlmap/internal/gen"
+)
+
+// options are the plugin options set in the sqlc configuration.
+type options struct {
+	// Package is the name of the package sqlc generates the queries in. Defaults to the base name of the out directory.
+	Package string `json:"package"`
+	// Filename is the name of the generated file. Defaults to sqlmap.go.
+	Filename string `json:"filename"`
+}
+
+// mapping is a single field assignment between a sqlc struct and its native counterpart.
+type mapping struct {
+	field  string
+	sqlc   string
+	domain string
+}
+
+// generator collects the declarations and imports of the generated file.
+type generator struct {
+	catalog catalog
+	imports map[string]bool
+	decls   bytes.Buffer
+	done    map[string]bool
+	errs    []error
+}
+
+// generate returns the files generated for the request.
+func generate(req generateRequest) ([]file, error) {
+	if req.settings.engine != "" && req.settings.engine != "postgresql" {
+		return nil, fmt.Errorf("engine %s is not supported", req.settings.engine)
+	}
+
+	var opts options
+
+	if len(req.pluginOptions) > 0 {
+		if err := json.Unmarshal(req.pluginOptions, &opts); err != nil {
+			return nil, fmt.Errorf("invalid plugin options: %w", err)
+		}
+	}
+
+	if opts.Package == "" {
+		opts.Package = path.Base(req.settings.out)
+	}
+
+	if opts.Filename == "" {
+		opts.Filename = "sqlmap.go"
+	}
+
+	g := &generator{catalog: req.catalog, imports: map[string]bool{}, done: map[string]bool{}}
+
+	for _, q := range req.queries {
+		g.query(q)
+	}
+
+	if len(g.errs) > 0 {
+		return nil, errors.Join(g.errs...)
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by sqlc-gen-sqlmap. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for p := range g.imports {
+			paths = append(paths, p)
+		}
+
+		// Standard library imports are grouped before the others, as goimports does.
+		slices.SortFunc(paths, func(a, b string) int {
+			if std(a) != std(b) {
+				if std(a) {
+					return -1
+				}
+
+				return 1
+			}
+
+			return strings.Compare(a, b)
+		})
+
+		fmt.Fprintf(&b, "import (\n")
+
+		for i, p := range paths {
+			if i > 0 && std(p) != std(paths[i-1]) {
+				fmt.Fprintln(&b)
+			}
+
+			fmt.Fprintf(&b, "%q\n", p)
+		}
+
+		fmt.Fprintf(&b, ")\n\n")
+	}
+
+	b.Write(g.decls.Bytes())
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return []file{{name: opts.Filename, contents: code}}, nil
+}
+
+// query emits the helpers for the Params struct and the row struct sqlc generates for the query, if any.
+func (g *generator) query(q query) {
+	// sqlc only generates a Params struct for queries with more than one parameter.
+	if len(q.params) > 1 {
+		columns := make([]column, len(q.params))
+
+		for i, p := range q.params {
+			columns[i] = p.column
+			if columns[i].name == "" {
+				columns[i].name = fmt.Sprintf("column_%d", p.number)
+			}
+		}
+
+		g.params(q.name, columns)
+	}
+
+	// sqlc only generates a row struct for queries returning more than one column.
+	if (q.cmd == ":one" || q.cmd == ":many") && len(q.columns) > 1 {
+		g.row(q.name, q.columns)
+	}
+}
+
+// params emits the native input struct for the query and its ToParams method.
+// ToParams also returns an error if any of the conversions may fail.
+func (g *generator) params(name string, columns []column) {
+	mappings := g.mappings(columns)
+	input := name + "Input"
+	params := name + "Params"
+
+	g.structDecl(input, fmt.Sprintf("holds the parameters of the %s query as native go types.", name), mappings)
+
+	exprs, fallible := g.converts(mappings, "in.", true)
+
+	fmt.Fprintf(&g.decls, "// ToParams converts the input to the parameters of the %s query.\n", name)
+
+	if !fallible {
+		fmt.Fprintf(&g.decls, "func (in %s) ToParams() %s {\n", input, params)
+		fmt.Fprintf(&g.decls, "return %s{\n", params)
+
+		for i, m := range mappings {
+			fmt.Fprintf(&g.decls, "%s: %s,\n", m.field, exprs[i].x)
+		}
+
+		fmt.Fprintf(&g.decls, "}\n}\n\n")
+
+		return
+	}
+
+	fmt.Fprintf(&g.decls, "func (in %s) ToParams() (%s, error) {\n", input, params)
+	fmt.Fprintf(&g.decls, "var (\np %s\nerr error\n)\n\n", params)
+
+	for i, m := range mappings {
+		g.assign("p."+m.field, exprs[i], params+"{}, err")
+	}
+
+	fmt.Fprintf(&g.decls, "\nreturn p, nil\n}\n\n")
+}
+
+// row emits the native output struct for the rows of the query and its FromModel method.
+// Rows matching a table are scanned into the table's model, whose helpers are only emitted once.
+func (g *generator) row(name string, columns []column) {
+	model, ok := g.model(columns)
+	if !ok {
+		model = name + "Row"
+	}
+
+	if g.done[model] {
+		return
+	}
+
+	g.done[model] = true
+
+	mappings := g.mappings(columns)
+	output := strings.TrimSuffix(model, "Row") + "Output"
+
+	g.structDecl(output, fmt.Sprintf("holds a %s as native go types.", model), mappings)
+
+	exprs, fallible := g.converts(mappings, "m.", false)
+
+	fmt.Fprintf(&g.decls, "// FromModel fills the output from a %s.\n", model)
+
+	if !fallible {
+		fmt.Fprintf(&g.decls, "func (out *%s) FromModel(m %s) {\n", output, model)
+
+		for i, m := range mappings {
+			fmt.Fprintf(&g.decls, "out.%s = %s\n", m.field, exprs[i].x)
+		}
+
+		fmt.Fprintf(&g.decls, "}\n\n")
+
+		return
+	}
+
+	fmt.Fprintf(&g.decls, "func (out *%s) FromModel(m %s) error {\n", output, model)
+	fmt.Fprintf(&g.decls, "var err error\n\n")
+
+	for i, m := range mappings {
+		g.assign("out."+m.field, exprs[i], "err")
+	}
+
+	fmt.Fprintf(&g.decls, "\nreturn nil\n}\n\n")
+}
+
+// mappings returns the field mappings of the columns.
+func (g *generator) mappings(columns []column) []mapping {
+	mappings := make([]mapping, 0, len(columns))
+
+	for _, c := range columns {
+		sqlc, domain := g.columnTypes(c)
+		mappings = append(mappings, mapping{field: structName(c.name), sqlc: sqlc, domain: domain})
+	}
+
+	return mappings
+}
+
+// expr is a conversion expression, which also returns an error if it is fallible.
+type expr struct {
+	x        string
+	fallible bool
+}
+
+// converts returns the expressions converting the fields of the mappings, either to the sqlc types or from them,
+// and whether any of them may fail.
+func (g *generator) converts(mappings []mapping, recv string, toSqlc bool) ([]expr, bool) {
+	exprs := make([]expr, len(mappings))
+	fallible := false
+
+	for i, m := range mappings {
+		dst, src := m.domain, m.sqlc
+		if toSqlc {
+			dst, src = src, dst
+		}
+
+		exprs[i] = g.convert(dst, src, recv+m.field)
+		fallible = fallible || exprs[i].fallible
+	}
+
+	return exprs, fallible
+}
+
+// assign emits the assignment of the expression to lhs, returning the results early if a fallible expression fails.
+func (g *generator) assign(lhs string, e expr, results string) {
+	if !e.fallible {
+		fmt.Fprintf(&g.decls, "%s = %s\n", lhs, e.x)
+
+		return
+	}
+
+	fmt.Fprintf(&g.decls, "if %s, err = %s; err != nil {\nreturn %s\n}\n", lhs, e.x, results)
+}
+
+// structDecl emits a struct declaration holding the native type of each mapping.
+func (g *generator) structDecl(name, doc string, mappings []mapping) {
+	fmt.Fprintf(&g.decls, "// %s %s\n", name, doc)
+	fmt.Fprintf(&g.decls, "type %s struct {\n", name)
+
+	for _, m := range mappings {
+		fmt.Fprintf(&g.decls, "%s %s\n", m.field, g.typeName(m.domain))
+	}
+
+	fmt.Fprintf(&g.decls, "}\n\n")
+}
+
+// convert returns the expression converting x from the src type to the dst type.
+func (g *generator) convert(dst, src, x string) expr {
+	name, ok := gen.Func(dst, src)
+	if !ok {
+		g.errs = append(g.errs, fmt.Errorf("no conversion from %s to %s", src, dst))
+
+		return expr{x: x}
+	}
+
+	if name == "" {
+		return expr{x: x}
+	}
+
+	g.imports[gen.ImportPath] = true
+
+	return expr{x: fmt.Sprintf("sqlmap.%s(%s)", name, x), fallible: gen.Fallible(name)}
+}
+
+// typeName returns the go syntax of the fully qualified type, recording its import.
+func (g *generator) typeName(t string) string {
+	prefix := ""
+
+	for _, p := range []string{"[]", "*"} {
+		if strings.HasPrefix(t, p) {
+			prefix, t = prefix+p, strings.TrimPrefix(t, p)
+		}
+	}
+
+	i := strings.LastIndex(t, ".")
+	if i < 0 {
+		return prefix + t
+	}
+
+	g.imports[t[:i]] = true
+
+	return prefix + path.Base(t[:i]) + t[i:]
+}
+
+// model returns the name of the model struct sqlc scans the columns into, if they match a table exactly.
+func (g *generator) model(columns []column) (string, bool) {
+	rel := columns[0].table
+
+	for _, s := range g.catalog.schemas {
+		schemaName := rel.schema
+		if schemaName == "" {
+			schemaName = g.catalog.defaultSchema
+		}
+
+		if s.name != schemaName {
+			continue
+		}
+
+		for _, t := range s.tables {
+			if t.rel.name != rel.name || !sameColumns(t.columns, columns) {
+				continue
+			}
+
+			name := singular(t.rel.name)
+			if s.name != g.catalog.defaultSchema {
+				name = s.name + "_" + name
+			}
+
+			return structName(name), true
+		}
+	}
+
+	return "", false
+}
+
+// sameColumns reports whether the query columns are exactly the table columns.
+func sameColumns(table, query []column) bool {
+	return slices.EqualFunc(table, query, func(a, b column) bool {
+		return a.name == b.name && a.notNull == b.notNull && a.isArray == b.isArray && a.typ.name == b.typ.name
+	})
+}
+
+// structName converts a column or table name to a go identifier the way sqlc does, e.g. owner_id to OwnerID.
+func structName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return '_'
+	}, name)
+
+	var b strings.Builder
+
+	for _, p := range strings.Split(name, "_") {
+		if p == "id" {
+			b.WriteString("ID")
+
+			continue
+		}
+
+		for i, r := range p {
+			if i == 0 {
+				r = unicode.ToUpper(r)
+			}
+
+			b.WriteRune(r)
+		}
+	}
+
+	out := b.String()
+	if out != "" && unicode.IsDigit(rune(out[0])) {
+		out = "_" + out
+	}
+
+	return out
+}
+
+// singular returns the singular form of a plural table name, covering the regular english forms sqlc handles.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"), strings.HasSuffix(name, "us"), strings.HasSuffix(name, "is"):
+		return name
+	case strings.HasSuffix(name, "s"):
+		return strings.TrimSuffix(name, "s")
+	}
+
+	return name
+}
+
+// std reports whether the import path belongs to the standard library.
+func std(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,71 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+// Command sqlc-gen-sqlmap is an sqlc process plugin generating mapping helpers alongside the queries generated by sqlc-gen-go.
+//
+// For every query with a Params struct it emits a <Query>Input struct of native go types with a ToParams method,
+// and for every row or model struct a <Model>Output struct with a FromModel method, both using the sqlmap converters.
+// Nullable numeric columns are exposed as a sqlmap.NullNumeric, which makes both methods return an error as well.
+// Enums and columns of types sqlmap has no converter for keep the type sqlc generates.
+// Point the plugin at the same directory and package as the go code generated with the database/sql package:
+//
+//	version: "2"
+//	plugins:
+//	  - name: sqlmap
+//	    process:
+//	      cmd: sqlc-gen-sqlmap
+//	sql:
+//	  - engine: postgresql
+//	    schema: schema.sql
+//	    queries: query.sql
+//	    gen:
+//	      go:
+//	        package: datastore
+//	        out: datastore
+//	    codegen:
+//	      - plugin: sqlmap
+//	        out: datastore
+//	        options:
+//	          package: datastore
+//
+// sqlc writes an encoded plugin.GenerateRequest to stdin and reads the encoded plugin.GenerateResponse from stdout.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "sqlc-gen-sqlmap:", err)
+		os.Exit(1)
+	}
+}
+
+// run decodes the request read from r and writes the generated response to w.
+func run(r io.Reader, w io.Writer) error {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	req, err := decodeGenerateRequest(in)
+	if err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	files, err := generate(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(encodeGenerateResponse(files))
+
+	return err
+}
@@ -0,0 +1,205 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// decodeGenerateResponse decodes the files of a plugin.GenerateResponse message.
+func decodeGenerateResponse(t *testing.T, b []byte) []file {
+	t.Helper()
+
+	var files []file
+
+	err := decodeFields(b, func(f field) error {
+		var out file
+
+		err := decodeFields(f.bytes, func(f field) error {
+			switch f.num {
+			case 1:
+				out.name = string(f.bytes)
+			case 2:
+				out.contents = f.bytes
+			}
+
+			return nil
+		})
+
+		files = append(files, out)
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to decode response: '%v'", err)
+	}
+
+	return files
+}
+
+func TestRun(t *testing.T) {
+	// generate_request.bin is the request sqlc sends for a foos table with
+	// CreateFoo :one, GetFoo :one, ListFooSummaries :many and DeleteFoo :exec queries.
+	in, err := os.Open("testdata/generate_request.bin")
+	if err != nil {
+		t.Fatalf("failed to open fixture: '%v'", err)
+	}
+
+	defer in.Close()
+
+	var out bytes.Buffer
+
+	if err := run(in, &out); err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	files := decodeGenerateResponse(t, out.Bytes())
+	if len(files) != 1 || files[0].name != "sqlmap.go" {
+		t.Fatalf("response should hold sqlmap.go, got: '%v'", files)
+	}
+
+	compareGolden(t, "testdata/sqlmap.go.golden", files[0].contents)
+}
+
+// compareGolden compares the generated code with the golden file, updating it first if the -update flag is set.
+func compareGolden(t *testing.T, golden string, contents []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.WriteFile(golden, contents, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: '%v'", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: '%v'", err)
+	}
+
+	if !bytes.Equal(contents, expected) {
+		t.Fatalf("result mismatch got:\n%s\nexpected:\n%s", contents, expected)
+	}
+}
+
+func TestGenerateEnumAndNumeric(t *testing.T) {
+	// An orders table with a nullable order_status enum column and a nullable numeric column,
+	// which can only be converted to a NullNumeric with an error.
+	orders := []column{
+		{name: "id", notNull: true, table: identifier{name: "orders"}, typ: identifier{name: "int8"}},
+		{name: "status", table: identifier{name: "orders"}, typ: identifier{name: "order_status"}},
+		{name: "total", table: identifier{name: "orders"}, typ: identifier{name: "pg_catalog.numeric"}},
+		{name: "receipt", table: identifier{name: "orders"}, typ: identifier{name: "bytea"}},
+	}
+
+	req := generateRequest{
+		settings: settings{engine: "postgresql", out: "db"},
+		catalog: catalog{
+			defaultSchema: "public",
+			schemas: []schema{
+				{name: "public", tables: []table{{rel: identifier{name: "orders"}, columns: orders}}, enums: []string{"order_status"}},
+			},
+		},
+		queries: []query{
+			{name: "GetOrder", cmd: ":one", columns: orders},
+			{
+				name: "UpdateOrder",
+				cmd:  ":exec",
+				params: []parameter{
+					{number: 1, column: orders[1]},
+					{number: 2, column: orders[2]},
+					{number: 3, column: orders[0]},
+				},
+			},
+		},
+	}
+
+	files, err := generate(req)
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	compareGolden(t, "testdata/enum_numeric.go.golden", files[0].contents)
+}
+
+func TestGeneratePassThroughColumn(t *testing.T) {
+	req := generateRequest{
+		settings: settings{out: "db"},
+		queries: []query{
+			{
+				name: "GetDoc",
+				cmd:  ":one",
+				columns: []column{
+					{name: "id", notNull: true, typ: identifier{name: "int8"}},
+					{name: "body", typ: identifier{name: "jsonb"}},
+					{name: "search", typ: identifier{name: "tsvector"}},
+				},
+			},
+		},
+	}
+
+	files, err := generate(req)
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	for _, expected := range []string{
+		"Body   pqtype.NullRawMessage",
+		"Search interface{}",
+		"out.Body = m.Body",
+		"out.Search = m.Search",
+	} {
+		if !strings.Contains(string(files[0].contents), expected) {
+			t.Errorf("generated code should contain '%s', got:\n%s", expected, files[0].contents)
+		}
+	}
+}
+
+func TestGenerateUnsupportedEngine(t *testing.T) {
+	if _, err := generate(generateRequest{settings: settings{engine: "mysql"}}); err == nil {
+		t.Fatalf("function should return error")
+	}
+}
+
+func TestStructName(t *testing.T) {
+	testCases := map[string]string{
+		"bar":        "Bar",
+		"owner_id":   "OwnerID",
+		"created-at": "CreatedAt",
+		"1st":        "_1st",
+		"column_2":   "Column2",
+	}
+
+	for input, expected := range testCases {
+		if result := structName(input); result != expected {
+			t.Errorf("result: '%s' does not equal expected: '%s'", result, expected)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	testCases := map[string]string{
+		"foos":      "foo",
+		"companies": "company",
+		"addresses": "address",
+		"boxes":     "box",
+		"status":    "status",
+		"user":      "user",
+	}
+
+	for input, expected := range testCases {
+		if result := singular(input); result != expected {
+			t.Errorf("result: '%s' does not equal expected: '%s'", result, expected)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The types in this file mirror the subset of the sqlc plugin protocol (protos/plugin/codegen.proto) used by the plugin.
+// They are decoded by hand so the plugin does not depend on sqlc itself.
+
+// generateRequest is the plugin.GenerateRequest message sqlc writes to the plugin's stdin.
+type generateRequest struct {
+	settings      settings
+	catalog       catalog
+	queries       []query
+	pluginOptions []byte
+}
+
+// settings is the plugin.Settings message.
+type settings struct {
+	engine string
+	out    string
+}
+
+// catalog is the plugin.Catalog message.
+type catalog struct {
+	defaultSchema string
+	schemas       []schema
+}
+
+// schema is the plugin.Schema message.
+type schema struct {
+	name   string
+	tables []table
+	enums  []string
+}
+
+// table is the plugin.Table message.
+type table struct {
+	rel     identifier
+	columns []column
+}
+
+// query is the plugin.Query message.
+type query struct {
+	name    string
+	cmd     string
+	columns []column
+	params  []parameter
+}
+
+// parameter is the plugin.Parameter message.
+type parameter struct {
+	number int32
+	column column
+}
+
+// column is the plugin.Column message.
+type column struct {
+	name    string
+	notNull bool
+	isArray bool
+	table   identifier
+	typ     identifier
+}
+
+// identifier is the plugin.Identifier message.
+type identifier struct {
+	schema string
+	name   string
+}
+
+// file is the plugin.File message.
+type file struct {
+	name     string
+	contents []byte
+}
+
+// field is a single decoded protobuf field.
+// Only the value matching the wire type is set.
+type field struct {
+	num    protowire.Number
+	varint uint64
+	bytes  []byte
+}
+
+// decodeFields calls fn for every field of the encoded message.
+func decodeFields(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		b = b[n:]
+		f := field{num: num}
+
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+
+		if n < 0 {
+			return fmt.Errorf("field %d: %w", num, protowire.ParseError(n))
+		}
+
+		b = b[n:]
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeGenerateRequest decodes a plugin.GenerateRequest message.
+func decodeGenerateRequest(b []byte) (generateRequest, error) {
+	var req generateRequest
+
+	err := decodeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return decodeSettings(f.bytes, &req.settings)
+		case 2:
+			return decodeCatalog(f.bytes, &req.catalog)
+		case 3:
+			var q query
+			if err := decodeQuery(f.bytes, &q); err != nil {
+				return err
+			}
+
+			req.queries = append(req.queries, q)
+		case 5:
+			req.pluginOptions = f.bytes
+		}
+
+		return nil
+	})
+
+	return req, err
+}
+
+// decodeSettings decodes a plugin.Settings message, including the out directory of its plugin.Codegen message.
+func decodeSettings(b []byte, s *settings) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 2:
+			s.engine = string(f.bytes)
+		case 12:
+			return decodeFields(f.bytes, func(f field) error {
+				if f.num == 1 {
+					s.out = string(f.bytes)
+				}
+
+				return nil
+			})
+		}
+
+		return nil
+	})
+}
+
+// decodeCatalog decodes a plugin.Catalog message.
+func decodeCatalog(b []byte, c *catalog) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 2:
+			c.defaultSchema = string(f.bytes)
+		case 4:
+			var s schema
+			if err := decodeSchema(f.bytes, &s); err != nil {
+				return err
+			}
+
+			c.schemas = append(c.schemas, s)
+		}
+
+		return nil
+	})
+}
+
+// decodeSchema decodes a plugin.Schema message.
+func decodeSchema(b []byte, s *schema) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 2:
+			s.name = string(f.bytes)
+		case 3:
+			var t table
+			if err := decodeTable(f.bytes, &t); err != nil {
+				return err
+			}
+
+			s.tables = append(s.tables, t)
+		case 4:
+			// Only the name of the plugin.Enum message is used.
+			return decodeFields(f.bytes, func(f field) error {
+				if f.num == 1 {
+					s.enums = append(s.enums, string(f.bytes))
+				}
+
+				return nil
+			})
+		}
+
+		return nil
+	})
+}
+
+// decodeTable decodes a plugin.Table message.
+func decodeTable(b []byte, t *table) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return decodeIdentifier(f.bytes, &t.rel)
+		case 2:
+			var c column
+			if err := decodeColumn(f.bytes, &c); err != nil {
+				return err
+			}
+
+			t.columns = append(t.columns, c)
+		}
+
+		return nil
+	})
+}
+
+// decodeQuery decodes a plugin.Query message.
+func decodeQuery(b []byte, q *query) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 2:
+			q.name = string(f.bytes)
+		case 3:
+			q.cmd = string(f.bytes)
+		case 4:
+			var c column
+			if err := decodeColumn(f.bytes, &c); err != nil {
+				return err
+			}
+
+			q.columns = append(q.columns, c)
+		case 5:
+			var p parameter
+			if err := decodeParameter(f.bytes, &p); err != nil {
+				return err
+			}
+
+			q.params = append(q.params, p)
+		}
+
+		return nil
+	})
+}
+
+// decodeParameter decodes a plugin.Parameter message.
+func decodeParameter(b []byte, p *parameter) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			p.number = int32(f.varint)
+		case 2:
+			return decodeColumn(f.bytes, &p.column)
+		}
+
+		return nil
+	})
+}
+
+// decodeColumn decodes a plugin.Column message.
+func decodeColumn(b []byte, c *column) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			c.name = string(f.bytes)
+		case 3:
+			c.notNull = f.varint != 0
+		case 4:
+			c.isArray = f.varint != 0
+		case 10:
+			return decodeIdentifier(f.bytes, &c.table)
+		case 12:
+			return decodeIdentifier(f.bytes, &c.typ)
+		}
+
+		return nil
+	})
+}
+
+// decodeIdentifier decodes a plugin.Identifier message.
+func decodeIdentifier(b []byte, id *identifier) error {
+	return decodeFields(b, func(f field) error {
+		switch f.num {
+		case 2:
+			id.schema = string(f.bytes)
+		case 3:
+			id.name = string(f.bytes)
+		}
+
+		return nil
+	})
+}
+
+// encodeGenerateResponse encodes a plugin.GenerateResponse message holding the files.
+func encodeGenerateResponse(files []file) []byte {
+	var b []byte
+
+	for _, f := range files {
+		var fb []byte
+		fb = protowire.AppendTag(fb, 1, protowire.BytesType)
+		fb = protowire.AppendString(fb, f.name)
+		fb = protowire.AppendTag(fb, 2, protowire.BytesType)
+		fb = protowire.AppendBytes(fb, f.contents)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, fb)
+	}
+
+	return b
+}
@@ -0,0 +1,152 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/justinsimmons/sqlmap/internal/gen"
+)
+
+// goType describes the go types of a column.
+// Types are fully qualified, as understood by the gen package.
+type goType struct {
+	// value is the type sqlc generates for a NOT NULL column.
+	value string
+	// null is the type sqlc generates for a nullable column.
+	null string
+	// domain is the native type a nullable column is exposed as.
+	domain string
+}
+
+// The go types sqlc generates for PostgreSQL columns when using the database/sql package.
+var (
+	stringType  = goType{value: "string", null: "database/sql.NullString", domain: "*string"}
+	int64Type   = goType{value: "int64", null: "database/sql.NullInt64", domain: "*int64"}
+	int32Type   = goType{value: "int32", null: "database/sql.NullInt32", domain: "*int32"}
+	int16Type   = goType{value: "int16", null: "database/sql.NullInt16", domain: "*int16"}
+	float64Type = goType{value: "float64", null: "database/sql.NullFloat64", domain: "*float64"}
+	float32Type = goType{value: "float32", null: "database/sql.NullFloat64", domain: "*float64"}
+	boolType    = goType{value: "bool", null: "database/sql.NullBool", domain: "*bool"}
+	timeType    = goType{value: "time.Time", null: "database/sql.NullTime", domain: "*time.Time"}
+	uuidType    = goType{value: "github.com/google/uuid.UUID", null: "github.com/google/uuid.NullUUID", domain: "*github.com/google/uuid.UUID"}
+	numericType = goType{value: "string", null: "database/sql.NullString", domain: gen.ImportPath + ".NullNumeric"}
+	bytesType   = goType{value: "[]byte", null: "[]byte", domain: gen.ImportPath + ".NullBytes"}
+)
+
+// The go types sqlc generates for PostgreSQL columns that are passed through unchanged.
+var (
+	moneyType    = goType{value: "string", null: "database/sql.NullString", domain: "database/sql.NullString"}
+	intervalType = goType{value: "int64", null: "database/sql.NullInt64", domain: "database/sql.NullInt64"}
+	jsonType     = goType{value: "encoding/json.RawMessage", null: "github.com/sqlc-dev/pqtype.NullRawMessage", domain: "github.com/sqlc-dev/pqtype.NullRawMessage"}
+	inetType     = goType{value: "github.com/sqlc-dev/pqtype.Inet", null: "github.com/sqlc-dev/pqtype.Inet", domain: "github.com/sqlc-dev/pqtype.Inet"}
+	cidrType     = goType{value: "github.com/sqlc-dev/pqtype.CIDR", null: "github.com/sqlc-dev/pqtype.CIDR", domain: "github.com/sqlc-dev/pqtype.CIDR"}
+	macaddrType  = goType{value: "net.HardwareAddr", null: "net.HardwareAddr", domain: "net.HardwareAddr"}
+	anyType      = goType{value: "interface{}", null: "interface{}", domain: "interface{}"}
+)
+
+// postgresTypes maps PostgreSQL column types to their go types.
+var postgresTypes = map[string]goType{
+	"text":                        stringType,
+	"varchar":                     stringType,
+	"character varying":           stringType,
+	"bpchar":                      stringType,
+	"char":                        stringType,
+	"character":                   stringType,
+	"citext":                      stringType,
+	"name":                        stringType,
+	"int8":                        int64Type,
+	"bigint":                      int64Type,
+	"bigserial":                   int64Type,
+	"serial8":                     int64Type,
+	"int4":                        int32Type,
+	"int":                         int32Type,
+	"integer":                     int32Type,
+	"serial":                      int32Type,
+	"serial4":                     int32Type,
+	"int2":                        int16Type,
+	"smallint":                    int16Type,
+	"smallserial":                 int16Type,
+	"serial2":                     int16Type,
+	"float8":                      float64Type,
+	"double precision":            float64Type,
+	"float4":                      float32Type,
+	"real":                        float32Type,
+	"bool":                        boolType,
+	"boolean":                     boolType,
+	"date":                        timeType,
+	"timestamp":                   timeType,
+	"timestamptz":                 timeType,
+	"timestamp without time zone": timeType,
+	"timestamp with time zone":    timeType,
+	"time":                        timeType,
+	"timetz":                      timeType,
+	"time without time zone":      timeType,
+	"time with time zone":         timeType,
+	"uuid":                        uuidType,
+	"numeric":                     numericType,
+	"decimal":                     numericType,
+	"bytea":                       bytesType,
+	"blob":                        bytesType,
+	"money":                       moneyType,
+	"interval":                    intervalType,
+	"json":                        jsonType,
+	"jsonb":                       jsonType,
+	"inet":                        inetType,
+	"cidr":                        cidrType,
+	"macaddr":                     macaddrType,
+	"macaddr8":                    macaddrType,
+}
+
+// columnTypes returns the type sqlc generates for the column and the native type it is exposed as.
+// Enums and types sqlmap has no native type for are passed through, using sqlc's own type on both sides.
+func (g *generator) columnTypes(c column) (sqlc, domain string) {
+	t, ok := postgresTypes[strings.TrimPrefix(c.typ.name, "pg_catalog.")]
+	if !ok {
+		t, ok = g.enumType(c.typ)
+	}
+
+	if !ok {
+		t = anyType
+	}
+
+	switch {
+	case c.isArray:
+		return "[]" + t.value, "[]" + t.value
+	case c.notNull:
+		return t.value, t.value
+	}
+
+	return t.null, t.domain
+}
+
+// enumType returns the go types sqlc generates for the enum, if the type is an enum of the catalog.
+func (g *generator) enumType(typ identifier) (goType, bool) {
+	schemaName := typ.schema
+	if schemaName == "" {
+		schemaName = g.catalog.defaultSchema
+	}
+
+	for _, s := range g.catalog.schemas {
+		if s.name != schemaName || !slices.Contains(s.enums, typ.name) {
+			continue
+		}
+
+		name := typ.name
+		if s.name != g.catalog.defaultSchema {
+			name = s.name + "_" + name
+		}
+
+		name = structName(name)
+
+		return goType{value: name, null: "Null" + name, domain: "Null" + name}, true
+	}
+
+	return goType{}, false
+}
@@ -0,0 +1,271 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/types"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/justinsimmons/sqlmap/internal/gen"
+)
+
+// tagName is the struct tag used to override the name a field is matched by, as with sqlmap.Map.
+const tagName = "sqlmap"
+
+// field is a mappable struct field along with the selector used to access it.
+type field struct {
+	name     string
+	selector string
+	typ      types.Type
+}
+
+// generator collects the statements and imports of the generated function.
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string
+	stmts   []string
+	errs    []error
+}
+
+// generate returns the formatted source of a file declaring the function fn, which maps the src type to the dst type.
+func generate(p *pkg, src, dst, fn string) ([]byte, error) {
+	srcType, err := p.lookup(src)
+	if err != nil {
+		return nil, err
+	}
+
+	dstType, err := p.lookup(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{pkg: p.types, imports: map[string]string{}}
+
+	g.mapStruct(dstType, srcType, "dst", "src", "")
+
+	if len(g.errs) > 0 {
+		return nil, errors.Join(g.errs...)
+	}
+
+	srcName := types.TypeString(srcType, g.qualify)
+	dstName := types.TypeString(dstType, g.qualify)
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by sqlmap-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", p.types.Name())
+	g.writeImports(&b)
+	fmt.Fprintf(&b, "// %s maps a %s to a %s.\n", fn, srcName, dstName)
+	fmt.Fprintf(&b, "func %s(src %s) %s {\n", fn, srcName, dstName)
+	fmt.Fprintf(&b, "var dst %s\n\n", dstName)
+
+	for _, s := range g.stmts {
+		fmt.Fprintln(&b, s)
+	}
+
+	fmt.Fprintf(&b, "\nreturn dst\n}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// mapStruct emits an assignment for every field of the dst struct.
+// Nested structs without a direct conversion are mapped field by field.
+func (g *generator) mapStruct(dst, src types.Type, dstSel, srcSel, path string) {
+	srcFields := fields(src, srcSel)
+
+	for _, df := range fields(dst, dstSel) {
+		fieldPath := path + df.name
+
+		sf, ok := match(srcFields, df.name)
+		if !ok {
+			g.errs = append(g.errs, fmt.Errorf("field %s has no matching field in %s", fieldPath, types.TypeString(src, nil)))
+
+			continue
+		}
+
+		name, ok := gen.Func(types.TypeString(df.typ, nil), types.TypeString(sf.typ, nil))
+		switch {
+		case ok && name == "":
+			g.stmts = append(g.stmts, fmt.Sprintf("%s = %s", df.selector, sf.selector))
+		case ok && gen.Fallible(name):
+			g.errs = append(g.errs, fmt.Errorf("cannot map field %s from %s to %s, the conversion may fail", fieldPath, types.TypeString(sf.typ, nil), types.TypeString(df.typ, nil)))
+		case ok:
+			g.stmts = append(g.stmts, fmt.Sprintf("%s = %s.%s(%s)", df.selector, g.sqlmap(), name, sf.selector))
+		case plainStruct(df.typ) && plainStruct(sf.typ) && len(fields(df.typ, "")) == 0:
+			g.errs = append(g.errs, fmt.Errorf("field %s of type %s has no mappable fields", fieldPath, types.TypeString(df.typ, nil)))
+		case plainStruct(df.typ) && plainStruct(sf.typ):
+			g.mapStruct(df.typ, sf.typ, df.selector, sf.selector, fieldPath+".")
+		default:
+			g.errs = append(g.errs, fmt.Errorf("cannot map field %s from %s to %s", fieldPath, types.TypeString(sf.typ, nil), types.TypeString(df.typ, nil)))
+		}
+	}
+}
+
+// sqlmap returns the name the sqlmap package is imported as.
+func (g *generator) sqlmap() string {
+	g.imports[gen.ImportPath] = "sqlmap"
+
+	return "sqlmap"
+}
+
+// qualify returns the name a package is referenced by in the generated code, recording the import.
+func (g *generator) qualify(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+
+	g.imports[p.Path()] = p.Name()
+
+	return p.Name()
+}
+
+// writeImports writes the import declaration of the generated file, if anything is imported.
+func (g *generator) writeImports(b *bytes.Buffer) {
+	if len(g.imports) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+
+	slices.Sort(paths)
+
+	fmt.Fprintf(b, "import (\n")
+
+	for _, p := range paths {
+		if name := g.imports[p]; name != path.Base(p) {
+			fmt.Fprintf(b, "%s ", name)
+		}
+
+		fmt.Fprintf(b, "%q\n", p)
+	}
+
+	fmt.Fprintf(b, ")\n\n")
+}
+
+// fields returns the mappable fields of the struct type, including those promoted from embedded structs.
+// Fields tagged with `sqlmap:"-"` and embedded pointers are skipped, as with sqlmap.Map.
+// Like sqlmap.Map the exported fields of unexported embedded structs are promoted,
+// they are selected through the outer struct as the embedded field itself is not accessible.
+func fields(t types.Type, selector string) []field {
+	s := t.Underlying().(*types.Struct)
+
+	var (
+		direct   []field
+		promoted []field
+	)
+
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		tag := reflect.StructTag(s.Tag(i)).Get(tagName)
+
+		sel := selector + "." + f.Name()
+
+		if f.Embedded() {
+			if !f.Exported() {
+				sel = selector
+			}
+
+			if isStruct(f.Type()) {
+				promoted = append(promoted, fields(f.Type(), sel)...)
+			}
+
+			continue
+		}
+
+		if !f.Exported() || tag == "-" {
+			continue
+		}
+
+		name := f.Name()
+		if n, _, _ := strings.Cut(tag, ","); n != "" {
+			name = n
+		}
+
+		direct = append(direct, field{name: name, selector: sel, typ: f.Type()})
+	}
+
+	for _, f := range promoted {
+		if _, ok := match(direct, f.name); !ok {
+			direct = append(direct, f)
+		}
+	}
+
+	return direct
+}
+
+// match finds the field matched by the name.
+// An exact match is preferred over a match under Unicode case folding, e.g. ID and Id.
+func match(fields []field, name string) (field, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+
+	for _, f := range fields {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+
+	return field{}, false
+}
+
+// isStruct reports whether the type is a struct value, whose fields are promoted when it is embedded.
+func isStruct(t types.Type) bool {
+	if _, ok := t.(*types.Pointer); ok {
+		return false
+	}
+
+	_, ok := t.Underlying().(*types.Struct)
+
+	return ok
+}
+
+// plainStruct reports whether the type is a struct value that can be mapped field by field, as with sqlmap.Map.
+// Values such as time.Time and types implementing sql.Scanner or driver.Valuer are converted as a whole.
+func plainStruct(t types.Type) bool {
+	if !isStruct(t) {
+		return false
+	}
+
+	if n, ok := t.(*types.Named); ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "time" && n.Obj().Name() == "Time" {
+		return false
+	}
+
+	// sql.Scanner is implemented on the pointer, driver.Valuer usually on the value.
+	return !hasMethod(types.NewPointer(t), "Scan", 1, 1) && !hasMethod(types.NewPointer(t), "Value", 0, 2)
+}
+
+// hasMethod reports whether the method set of the type holds an exported method with the name,
+// the number of parameters and results, and an error as last result.
+func hasMethod(t types.Type, name string, params, results int) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != params || sig.Results().Len() != results {
+		return false
+	}
+
+	return types.Identical(sig.Results().At(results-1).Type(), types.Universe.Lookup("error").Type())
+}
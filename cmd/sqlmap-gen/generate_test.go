@@ -0,0 +1,196 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exampleDir = "testdata/example"
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    string
+		dst    string
+		fn     string
+		golden string
+	}{
+		{
+			name:   "should generate mapping from request to params",
+			src:    "CreateFooRequest",
+			dst:    "CreateFooParams",
+			fn:     "ToCreateFooParams",
+			golden: "testdata/to_create_foo_params.golden",
+		},
+		{
+			name:   "should generate mapping from model to response",
+			src:    "Foo",
+			dst:    "FooResponse",
+			fn:     "ToFooResponse",
+			golden: "testdata/to_foo_response.golden",
+		},
+		{
+			name:   "should generate mapping of fields promoted from unexported embedded struct",
+			src:    "TrackedRequest",
+			dst:    "TrackedParams",
+			fn:     "ToTrackedParams",
+			golden: "testdata/to_tracked_params.golden",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := load(exampleDir, "")
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			code, err := generate(p, tc.src, tc.dst, tc.fn)
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			expected, err := os.ReadFile(tc.golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: '%v'", err)
+			}
+
+			if !bytes.Equal(code, expected) {
+				t.Fatalf("result mismatch got:\n%s\nexpected:\n%s", code, expected)
+			}
+
+			// The generated code must compile alongside the package it is generated for.
+			// It is checked in a copy of the package, leaving the source tree untouched.
+			dir := copyExample(t)
+
+			if err := os.WriteFile(filepath.Join(dir, "zz_generated.go"), code, 0o644); err != nil {
+				t.Fatalf("failed to write generated code: '%v'", err)
+			}
+
+			if _, err := load(dir, ""); err != nil {
+				t.Fatalf("generated code should type check, got error: '%v'", err)
+			}
+		})
+	}
+}
+
+// copyExample copies the go files of the example package into a temporary directory.
+func copyExample(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	files, err := filepath.Glob(filepath.Join(exampleDir, "*.go"))
+	if err != nil {
+		t.Fatalf("failed to list example package: '%v'", err)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("failed to read example package: '%v'", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(f)), data, 0o644); err != nil {
+			t.Fatalf("failed to copy example package: '%v'", err)
+		}
+	}
+
+	return dir
+}
+
+func TestGenerateUnmappableFields(t *testing.T) {
+	p, err := load(exampleDir, "")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	_, err = generate(p, "Foo", "BrokenResponse", "ToBrokenResponse")
+	if err == nil {
+		t.Fatalf("function should return error")
+	}
+
+	for _, field := range []string{"Bar", "Missing"} {
+		if !strings.Contains(err.Error(), "field "+field+" ") {
+			t.Errorf("error should name field %s, got: '%v'", field, err)
+		}
+	}
+}
+
+func TestGenerateValueStructs(t *testing.T) {
+	p, err := load(exampleDir, "")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	// Neither field may be mapped field by field, as both sources are values implementing driver.Valuer.
+	_, err = generate(p, "EventRecord", "EventResponse", "ToEventResponse")
+	if err == nil {
+		t.Fatalf("function should return error")
+	}
+
+	for _, field := range []string{"CreatedAt", "Window"} {
+		if !strings.Contains(err.Error(), "field "+field+" ") {
+			t.Errorf("error should name field %s, got: '%v'", field, err)
+		}
+	}
+}
+
+func TestGenerateEmptyNestedStruct(t *testing.T) {
+	p, err := load(exampleDir, "")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if _, err := generate(p, "Outer", "EmptyOuter", "ToEmptyOuter"); err == nil || !strings.Contains(err.Error(), "no mappable fields") {
+		t.Fatalf("error should report the nested struct without mappable fields, got: '%v'", err)
+	}
+}
+
+func TestGenerateWithoutImports(t *testing.T) {
+	p, err := load(exampleDir, "")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	code, err := generate(p, "Outer", "OuterCopy", "ToOuterCopy")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if bytes.Contains(code, []byte("import")) {
+		t.Fatalf("generated code should not declare imports, got:\n%s", code)
+	}
+}
+
+func TestGenerateFallibleConversion(t *testing.T) {
+	p, err := load(exampleDir, "")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if _, err := generate(p, "NumericRecord", "NumericResponse", "ToNumericResponse"); err == nil || !strings.Contains(err.Error(), "field Amount ") {
+		t.Fatalf("error should name field Amount, got: '%v'", err)
+	}
+}
+
+func TestGenerateUnknownType(t *testing.T) {
+	p, err := load(exampleDir, "")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if _, err := generate(p, "Foo", "DoesNotExist", "ToDoesNotExist"); err == nil {
+		t.Fatalf("function should return error")
+	}
+}
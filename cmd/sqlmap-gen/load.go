@@ -0,0 +1,121 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pkg is the type checked package the mapping function is generated in.
+type pkg struct {
+	types    *types.Package
+	importer types.Importer
+}
+
+var (
+	// fset holds the positions of every file parsed by the generator.
+	fset = token.NewFileSet()
+
+	// sourceImporter type checks imported packages from source.
+	// It is shared between loads so each imported package is only checked once.
+	sourceImporter = importer.ForCompiler(fset, "source", nil)
+)
+
+// load parses and type checks the package in the directory.
+// The skip file, the previous output of the generator, is ignored so stale generated code does not break generation.
+// Files excluded by build constraints for the current platform, such as those tagged ignore, are ignored as well.
+func load(dir, skip string) (*pkg, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == skip || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		match, err := build.Default.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !match {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, f)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no go files in %s", dir)
+	}
+
+	var errs []error
+
+	conf := types.Config{
+		Importer: sourceImporter,
+		Error:    func(err error) { errs = append(errs, err) },
+	}
+
+	p, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &pkg{types: p, importer: sourceImporter}, nil
+}
+
+// lookup resolves a type reference, either the name of a type in the package or an import path followed by a type name.
+func (p *pkg) lookup(ref string) (*types.Named, error) {
+	scope := p.types.Scope()
+	name := ref
+
+	if i := strings.LastIndex(ref, "."); i >= 0 {
+		imported, err := p.importer.Import(ref[:i])
+		if err != nil {
+			return nil, err
+		}
+
+		scope, name = imported.Scope(), ref[i+1:]
+	}
+
+	obj, ok := scope.Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", ref)
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", ref)
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("%s is not a struct type", ref)
+	}
+
+	return named, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+// Command sqlmap-gen generates a function mapping one struct type to another field by field using the sqlmap converters.
+// It is the compile time checked alternative to sqlmap.Map and sqlmap.MapModel.
+//
+// It is meant to be run by go generate from the package that will contain the generated function:
+//
+//	//go:generate go run github.com/justinsimmons/sqlmap/cmd/sqlmap-gen -src CreateFooRequest -dst example.com/app/datastore.CreateFooParams
+//
+// Types of the current package are referenced by name, types of other packages by import path and name.
+// Fields are matched like sqlmap.Map matches them, and generation fails if any destination field cannot be mapped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		src  = flag.String("src", "", "source struct `type`")
+		dst  = flag.String("dst", "", "destination struct `type`")
+		fn   = flag.String("func", "", "name of the generated function (default To<dst>)")
+		out  = flag.String("o", "", "output `file` (default <func>_sqlmap.go)")
+		dir  = flag.String("dir", ".", "`directory` of the package the function is generated in")
+		help = flag.Bool("h", false, "print usage")
+	)
+
+	flag.Parse()
+
+	if *help || *src == "" || *dst == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *fn == "" {
+		*fn = "To" + typeName(*dst)
+	}
+
+	if *out == "" {
+		*out = strings.ToLower(*fn) + "_sqlmap.go"
+	}
+
+	if err := run(*dir, *src, *dst, *fn, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "sqlmap-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the mapping function and writes it to the output file in the package directory.
+func run(dir, src, dst, fn, out string) error {
+	path := out
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, out)
+	}
+
+	pkg, err := load(dir, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	code, err := generate(pkg, src, dst, fn)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, code, 0o644)
+}
+
+// typeName returns the name of a possibly qualified type reference.
+func typeName(ref string) string {
+	return ref[strings.LastIndex(ref, ".")+1:]
+}
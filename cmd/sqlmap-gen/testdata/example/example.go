@@ -0,0 +1,105 @@
+// Package example holds the types sqlmap-gen is tested against.
+package example
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justinsimmons/sqlmap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Audit struct {
+	CreatedAt *timestamppb.Timestamp
+}
+
+type CreateFooRequest struct {
+	Audit
+	Bar     *string
+	Biz     string
+	Count   int64
+	Ratio   *float32
+	OwnerID *uuid.UUID `sqlmap:"owner"`
+	Ignored string     `sqlmap:"-"`
+	Address Address
+}
+
+type Address struct {
+	Street *string
+}
+
+type CreateFooParams struct {
+	Bar       sql.NullString
+	Biz       string
+	Count     sql.NullInt64
+	Ratio     sql.Null[float32]
+	CreatedAt sql.NullTime
+	Owner     uuid.NullUUID
+	Address   AddressParams
+}
+
+type AddressParams struct {
+	Street sql.NullString
+}
+
+type Foo struct {
+	Bar       sql.NullString
+	UpdatedAt sql.NullTime
+	Flag      sql.NullByte
+}
+
+type FooResponse struct {
+	Bar       *string
+	UpdatedAt time.Time
+	Flag      byte
+}
+
+type BrokenResponse struct {
+	Bar     int
+	Missing string
+}
+
+type tracking struct {
+	TraceID *string
+}
+
+type TrackedRequest struct {
+	tracking
+	Name string
+}
+
+type TrackedParams struct {
+	TraceID sql.NullString
+	Name    string
+}
+
+type EventRecord struct {
+	CreatedAt sqlmap.Date
+	Window    sql.NullTime
+}
+
+type EventResponse struct {
+	CreatedAt time.Time
+	Window    struct{ Time time.Time }
+}
+
+type Outer struct {
+	Inner struct{ Name string }
+}
+
+type EmptyOuter struct {
+	Inner struct{ name string }
+}
+
+type OuterCopy struct {
+	Inner struct{ Name string }
+}
+
+type NumericRecord struct {
+	Amount sql.NullString
+}
+
+type NumericResponse struct {
+	Amount sqlmap.NullNumeric
+}
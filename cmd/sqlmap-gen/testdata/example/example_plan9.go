@@ -0,0 +1,4 @@
+// This file only builds on plan9, loading it elsewhere would redeclare Foo.
+package example
+
+type Foo struct{}
@@ -0,0 +1,6 @@
+//go:build ignore
+
+// This file is excluded by its build constraint, loading it would redeclare Foo.
+package example
+
+type Foo struct{}
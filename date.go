@@ -0,0 +1,239 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date stored in a DATE column, without a time of day or time zone.
+// Unlike a time.Time at midnight it does not shift by a day when it is converted between time zones.
+//
+// Years before 1 AD follow the proleptic Gregorian calendar of time.Time, year 0 is 1 BC.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of the time in its location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses an ISO 8601 date, e.g. "2024-02-29", as well as the BC dates of the text output of a DATE column, e.g. "0044-03-15 BC".
+// Invalid input returns a *ConversionError wrapping ErrInvalidFormat.
+func ParseDate(in string) (Date, error) {
+	s, bc := strings.CutSuffix(in, " BC")
+
+	// The year may be longer than four digits, so the date is split manually rather than using time.Parse.
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 || len(parts[0]) < 4 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return Date{}, conversionError(in, "Date", ErrInvalidFormat, nil)
+	}
+
+	var fields [3]int
+
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return Date{}, conversionError(in, "Date", ErrInvalidFormat, err)
+		}
+
+		fields[i] = int(n)
+	}
+
+	d := Date{Year: fields[0], Month: time.Month(fields[1]), Day: fields[2]}
+	if bc {
+		d.Year = 1 - d.Year
+	}
+
+	if !d.IsValid() || (bc && fields[0] == 0) {
+		return Date{}, conversionError(in, "Date", ErrInvalidFormat, nil)
+	}
+
+	return d, nil
+}
+
+// IsValid reports whether the date exists, e.g. 2023-02-29 does not.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the time at midnight of the date in the location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns the date in the ISO 8601 format, or with a BC suffix like Postgres for years before 1 AD.
+func (d Date) String() string {
+	if d.Year < 1 {
+		return fmt.Sprintf("%04d-%02d-%02d BC", 1-d.Year, d.Month, d.Day)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Scan implements the sql.Scanner interface.
+// Use NullDate to scan nullable columns.
+func (d *Date) Scan(value any) error {
+	var n NullDate
+
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+
+	if !n.Valid {
+		return &ConversionError{Source: "NULL", Target: "Date", Err: fmt.Errorf("%w, use NullDate", ErrNull)}
+	}
+
+	*d = n.Date
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The date is written as a string so the driver does not convert it to another time zone.
+// Invalid dates, including the zero Date, return a *ConversionError wrapping ErrInvalidFormat.
+func (d Date) Value() (driver.Value, error) {
+	if !d.IsValid() {
+		return nil, conversionError(d, "DATE", ErrInvalidFormat, nil)
+	}
+
+	return d.String(), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Date) UnmarshalText(text []byte) error {
+	v, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
+
+// NullDate represents a Date that may be null.
+// Null is encoded as JSON null.
+type NullDate struct {
+	Date  Date
+	Valid bool // Valid is true if Date is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+// Drivers return DATE columns as a time.Time at midnight, its date is taken as is in the location of the time.
+// The text output of the column is accepted as well.
+func (n *NullDate) Scan(value any) error {
+	var err error
+
+	switch v := value.(type) {
+	case nil:
+		*n = NullDate{}
+
+		return nil
+	case time.Time:
+		n.Date = DateOf(v)
+	case []byte:
+		n.Date, err = ParseDate(string(v))
+	case string:
+		n.Date, err = ParseDate(v)
+	default:
+		err = conversionError(value, "NullDate", ErrUnsupported, nil)
+	}
+
+	if err != nil {
+		*n = NullDate{}
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullDate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Date.Value()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullDate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return strconv.AppendQuote(nil, n.Date.String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *NullDate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = NullDate{}
+
+		return nil
+	}
+
+	if err := n.Date.UnmarshalText(unquote(data)); err != nil {
+		*n = NullDate{}
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// NullDateOf converts the time or time pointer to a NullDate type holding its date in the location of the time.
+// Use t.In(loc) first to take the date in another location.
+func NullDateOf[T time.Time | *time.Time](t T) NullDate {
+	n := nullOf[time.Time](t)
+
+	return NullDate{Date: DateOf(n.V), Valid: n.Valid}
+}
+
+// UnwrapDate unwraps the NullDate to a Date pointer.
+// If the value is null the function will return nil.
+func UnwrapDate(n NullDate) *Date {
+	if !n.Valid {
+		return nil
+	}
+
+	return &n.Date
+}
+
+// UnwrapDateIn unwraps the NullDate to a pointer to the time at midnight of the date in the location.
+// If the value is null the function will return nil.
+func UnwrapDateIn(n NullDate, loc *time.Location) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+
+	t := n.Date.In(loc)
+
+	return &t
+}
@@ -0,0 +1,177 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected Date
+		fail     bool
+	}{
+		{name: "should parse date", input: "2024-02-29", expected: Date{Year: 2024, Month: time.February, Day: 29}},
+		{name: "should parse year above 9999", input: "10000-01-01", expected: Date{Year: 10000, Month: time.January, Day: 1}},
+		{name: "should parse BC date", input: "0044-03-15 BC", expected: Date{Year: -43, Month: time.March, Day: 15}},
+		{name: "should fail to parse non-existent date", input: "2023-02-29", fail: true},
+		{name: "should fail to parse timestamp", input: "2024-02-29T00:00:00Z", fail: true},
+		{name: "should fail to parse short fields", input: "2024-2-9", fail: true},
+		{name: "should fail to parse year zero BC", input: "0000-01-01 BC", fail: true},
+		{name: "should fail to parse random string", input: "foobar", fail: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseDate(tc.input)
+			if tc.fail {
+				if !errors.Is(err, ErrInvalidFormat) {
+					t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%+v', expected: '%+v'", result, tc.expected)
+			}
+
+			if result.String() != tc.input {
+				t.Fatalf("string mismatch got '%s', expected: '%s'", result, tc.input)
+			}
+		})
+	}
+}
+
+func TestDateTimeZones(t *testing.T) {
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+	d := Date{Year: 2024, Month: time.January, Day: 1}
+
+	t.Run("should keep the date of the time in its location", func(t *testing.T) {
+		// Midnight in Tokyo is still the previous day in UTC.
+		if result := DateOf(d.In(tokyo)); result != d {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, d)
+		}
+	})
+
+	t.Run("should scan time.Time without shifting the date", func(t *testing.T) {
+		var n NullDate
+
+		if err := n.Scan(d.In(tokyo)); err != nil || !n.Valid || n.Date != d {
+			t.Fatalf("result mismatch got '%+v' (%v), expected: '%v'", n, err, d)
+		}
+	})
+
+	t.Run("should write the date as a string", func(t *testing.T) {
+		if result, err := d.Value(); err != nil || result != "2024-01-01" {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '2024-01-01'", result, err)
+		}
+	})
+
+	t.Run("should fail to write invalid dates", func(t *testing.T) {
+		for _, invalid := range []Date{{}, {Year: 2023, Month: time.February, Day: 29}} {
+			if _, err := invalid.Value(); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat for '%v', got: '%v'", invalid, err)
+			}
+
+			if _, err := (NullDate{Date: invalid, Valid: true}).Value(); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat for '%v', got: '%v'", invalid, err)
+			}
+		}
+	})
+
+	t.Run("should unwrap to midnight in the location", func(t *testing.T) {
+		result := UnwrapDateIn(NullDate{Date: d, Valid: true}, tokyo)
+		if result == nil || !result.Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, tokyo)) {
+			t.Fatalf("result mismatch got '%v'", result)
+		}
+
+		if result := UnwrapDateIn(NullDate{}, tokyo); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+	})
+}
+
+func TestNullDateScan(t *testing.T) {
+	t.Run("successfully scan text output", func(t *testing.T) {
+		var n NullDate
+
+		if err := n.Scan([]byte("2024-02-29")); err != nil || n.Date != (Date{Year: 2024, Month: time.February, Day: 29}) {
+			t.Fatalf("result mismatch got '%+v' (%v)", n, err)
+		}
+	})
+
+	t.Run("successfully scan NULL", func(t *testing.T) {
+		n := NullDate{Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", n, err)
+		}
+	})
+
+	t.Run("should fail to scan NULL into Date", func(t *testing.T) {
+		var d Date
+
+		if err := d.Scan(nil); !errors.Is(err, ErrNull) {
+			t.Fatalf("error should wrap ErrNull, got: '%v'", err)
+		}
+	})
+}
+
+func TestNullDateJSON(t *testing.T) {
+	type resource struct {
+		Birthday NullDate `json:"birthday"`
+		Created  Date     `json:"created"`
+	}
+
+	input := resource{Birthday: NullDate{Date: Date{Year: 1990, Month: time.July, Day: 4}, Valid: true}, Created: Date{Year: 2024, Month: time.January, Day: 1}}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if expected := `{"birthday":"1990-07-04","created":"2024-01-01"}`; string(data) != expected {
+		t.Fatalf("result mismatch got '%s', expected: '%s'", data, expected)
+	}
+
+	var result resource
+
+	if err := json.Unmarshal(data, &result); err != nil || result != input {
+		t.Fatalf("round trip mismatch got '%+v' (%v), expected: '%+v'", result, err, input)
+	}
+
+	if err := json.Unmarshal([]byte(`{"birthday":null}`), &result); err != nil || result.Birthday.Valid {
+		t.Fatalf("result should be null, got: '%+v' (%v)", result.Birthday, err)
+	}
+}
+
+func TestNullDateOf(t *testing.T) {
+	now := time.Now()
+
+	if result := NullDateOf(now); !result.Valid || result.Date != DateOf(now) {
+		t.Fatalf("result mismatch got '%+v', expected: '%v'", result, DateOf(now))
+	}
+
+	if result := NullDateOf[*time.Time](nil); result.Valid {
+		t.Fatalf("result should be null, got: '%+v'", result)
+	}
+
+	if result := UnwrapDate(NullDateOf(&now)); result == nil || *result != DateOf(now) {
+		t.Fatalf("result mismatch got '%v', expected: '%v'", result, DateOf(now))
+	}
+}
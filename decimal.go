@@ -0,0 +1,305 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// NullNumeric represents an exact decimal number stored in a NUMERIC or DECIMAL column that may be null.
+// Unlike sql.NullFloat64 the value is scanned without loss of precision.
+// A valid NullNumeric with a nil Rat holds zero, like the zero big.Rat.
+//
+// Use NullDecimal and UnwrapDecimal to convert from and to a *big.Rat, and a NumericSpec to validate values against the column type.
+type NullNumeric struct {
+	Rat   *big.Rat
+	Valid bool // Valid is true if Rat is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the text output of NUMERIC columns, as well as the integers and floating point numbers returned by SQLite.
+// Floating point numbers are scanned as the shortest decimal that represents them, e.g. 0.1.
+func (n *NullNumeric) Scan(value any) error {
+	var err error
+
+	switch v := value.(type) {
+	case nil:
+		*n = NullNumeric{}
+
+		return nil
+	case int64:
+		n.Rat = new(big.Rat).SetInt64(v)
+	case float64:
+		n.Rat, err = parseDecimal(strconv.FormatFloat(v, 'g', -1, 64))
+	case []byte:
+		n.Rat, err = parseDecimal(string(v))
+	case string:
+		n.Rat, err = parseDecimal(v)
+	default:
+		err = conversionError(value, "NullNumeric", ErrUnsupported, nil)
+	}
+
+	if err != nil {
+		*n = NullNumeric{}
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The number is written as an exact decimal string.
+// Numbers without a finite decimal representation, e.g. 1/3, return an error wrapping ErrInexact.
+func (n NullNumeric) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	s, ok := formatDecimal(n.number())
+	if !ok {
+		return nil, conversionError(n.Rat, "NUMERIC", ErrInexact, nil)
+	}
+
+	return s, nil
+}
+
+// String returns the exact decimal representation of the number, or the empty string if it is null.
+// Numbers without a finite decimal representation are returned as a fraction, e.g. "1/3".
+func (n NullNumeric) String() string {
+	if !n.Valid {
+		return ""
+	}
+
+	if s, ok := formatDecimal(n.number()); ok {
+		return s
+	}
+
+	return n.Rat.String()
+}
+
+// number returns the value of the valid NullNumeric, treating a nil Rat as zero.
+func (n NullNumeric) number() *big.Rat {
+	if n.Rat == nil {
+		return new(big.Rat)
+	}
+
+	return n.Rat
+}
+
+// NumericSpec is the declared precision and scale of a NUMERIC(precision, scale) column.
+// A zero precision stands for a NUMERIC column without declared precision, which accepts any value.
+// Like in Postgres 15 and later the scale may be negative or greater than the precision,
+// e.g. NUMERIC(2,-3) holds multiples of 1000 up to 99000 and NUMERIC(2,5) values up to 0.00099.
+//
+//	var price = sqlmap.NumericSpec{Precision: 19, Scale: 4}
+//
+//	amount, err := price.NullDecimal(total)
+type NumericSpec struct {
+	Precision int
+	Scale     int
+}
+
+// Check validates the number against the column type. A nil number is treated as zero.
+// Numbers with more fractional digits than the scale return an error wrapping ErrInexact instead of being rounded by the database,
+// numbers with more integer digits than the precision minus the scale return an error wrapping ErrOverflow.
+// A negative precision returns an error wrapping ErrUnsupported.
+func (s NumericSpec) Check(r *big.Rat) error {
+	if s.Precision < 0 {
+		return &ConversionError{Target: s.String(), Err: fmt.Errorf("%w: negative precision", ErrUnsupported)}
+	}
+
+	if s.Precision == 0 || r == nil {
+		return nil
+	}
+
+	// Shifted by the scale, the number must be an integer with at most precision digits.
+	shifted := new(big.Rat).Mul(r, pow10(s.Scale))
+	if !shifted.IsInt() {
+		return conversionError(r, s.String(), ErrInexact, nil)
+	}
+
+	if new(big.Int).Abs(shifted.Num()).Cmp(pow10(s.Precision).Num()) >= 0 {
+		return conversionError(r, s.String(), ErrOverflow, nil)
+	}
+
+	return nil
+}
+
+// pow10 returns 10 to the power of the exponent, which may be negative.
+func pow10(exp int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(exp, -exp))), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+
+	return new(big.Rat).SetInt(p)
+}
+
+// NullDecimal converts the *big.Rat to a NullNumeric type, validating it against the column type.
+// A nil pointer is converted to null.
+func (s NumericSpec) NullDecimal(r *big.Rat) (NullNumeric, error) {
+	if r == nil {
+		return NullNumeric{}, nil
+	}
+
+	if err := s.Check(r); err != nil {
+		return NullNumeric{}, err
+	}
+
+	return NullDecimal(r), nil
+}
+
+// String returns the column type, e.g. "NUMERIC(19,4)".
+func (s NumericSpec) String() string {
+	if s.Precision == 0 {
+		return "NUMERIC"
+	}
+
+	return "NUMERIC(" + strconv.Itoa(s.Precision) + "," + strconv.Itoa(s.Scale) + ")"
+}
+
+// NullDecimal converts the *big.Rat to a NullNumeric type.
+// A nil pointer is converted to null. The value is copied, so later changes to it do not affect the result.
+// Use a NumericSpec to validate the value against the column type.
+func NullDecimal(r *big.Rat) NullNumeric {
+	if r == nil {
+		return NullNumeric{}
+	}
+
+	return NullNumeric{Rat: new(big.Rat).Set(r), Valid: true}
+}
+
+// NullDecimalFromFloat converts the *big.Float to a NullNumeric type holding its exact value.
+// A nil pointer is converted to null, infinite values return an error wrapping ErrInvalidFormat.
+func NullDecimalFromFloat(f *big.Float) (NullNumeric, error) {
+	if f == nil {
+		return NullNumeric{}, nil
+	}
+
+	if f.IsInf() {
+		return NullNumeric{}, conversionError(f, "NullNumeric", ErrInvalidFormat, nil)
+	}
+
+	r, _ := f.Rat(nil)
+
+	return NullNumeric{Rat: r, Valid: true}, nil
+}
+
+// NullNumericFromString parses the sql.NullString sqlc generates for NUMERIC columns into a NullNumeric type.
+// Text that is not a decimal number, such as NaN, returns a *ConversionError wrapping ErrInvalidFormat.
+func NullNumericFromString(s sql.NullString) (NullNumeric, error) {
+	var n NullNumeric
+
+	if !s.Valid {
+		return n, nil
+	}
+
+	err := n.Scan(s.String)
+
+	return n, err
+}
+
+// UnwrapNumericString unwraps the NullNumeric to the sql.NullString sqlc generates for NUMERIC columns.
+// Numbers without a finite decimal representation return an error wrapping ErrInexact.
+func UnwrapNumericString(n NullNumeric) (sql.NullString, error) {
+	v, err := n.Value()
+	if v == nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: v.(string), Valid: true}, nil
+}
+
+// UnwrapDecimal unwraps the NullNumeric to a *big.Rat.
+// If the value is null the function will return nil.
+// The value is copied, so changes to the result do not affect the NullNumeric.
+func UnwrapDecimal(n NullNumeric) *big.Rat {
+	if !n.Valid {
+		return nil
+	}
+
+	return new(big.Rat).Set(n.number())
+}
+
+// UnwrapDecimalFloat unwraps the NullNumeric to a *big.Float of the given precision in bits, rounding to nearest even.
+// If the value is null the function will return nil.
+func UnwrapDecimalFloat(n NullNumeric, prec uint) *big.Float {
+	if !n.Valid {
+		return nil
+	}
+
+	return new(big.Float).SetPrec(prec).SetRat(n.number())
+}
+
+// parseDecimal parses a decimal number, e.g. "-123.4500" or "1.5e3".
+// Special values such as NaN and Infinity, which have no exact representation, return a *ConversionError wrapping ErrInvalidFormat.
+func parseDecimal(in string) (*big.Rat, error) {
+	// big.Rat also accepts fractions such as "1/3", which are not decimal numbers.
+	if strings.Contains(in, "/") {
+		return nil, conversionError(in, "*big.Rat", ErrInvalidFormat, nil)
+	}
+
+	r, ok := new(big.Rat).SetString(in)
+	if !ok {
+		return nil, conversionError(in, "*big.Rat", ErrInvalidFormat, nil)
+	}
+
+	return r, nil
+}
+
+// formatDecimal returns the exact decimal representation of the number, reporting whether it has one.
+func formatDecimal(r *big.Rat) (string, bool) {
+	scale, ok := decimalScale(r)
+	if !ok {
+		return "", false
+	}
+
+	return r.FloatString(scale), true
+}
+
+// decimalScale returns the number of fractional digits of the exact decimal representation of the number,
+// reporting whether it has one. It does, if the denominator has no prime factors other than 2 and 5.
+func decimalScale(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+
+	var (
+		twos, fives int
+		m           big.Int
+		two, five   = big.NewInt(2), big.NewInt(5)
+	)
+
+	for q := new(big.Int); ; twos++ {
+		if q.DivMod(d, two, &m); m.Sign() != 0 {
+			break
+		}
+
+		d.Set(q)
+	}
+
+	for q := new(big.Int); ; fives++ {
+		if q.DivMod(d, five, &m); m.Sign() != 0 {
+			break
+		}
+
+		d.Set(q)
+	}
+
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+
+	return max(twos, fives), true
+}
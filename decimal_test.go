@@ -0,0 +1,269 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// rat parses the decimal number, failing the test if it is invalid.
+func rat(t *testing.T, s string) *big.Rat {
+	t.Helper()
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		t.Fatalf("invalid decimal '%s'", s)
+	}
+
+	return r
+}
+
+func TestNullNumericScan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    any
+		expected string
+		fail     bool
+	}{
+		{name: "should scan NUMERIC text exactly", input: []byte("12345678901234567.8901"), expected: "12345678901234567.8901"},
+		{name: "should scan negative NUMERIC string", input: "-0.0500", expected: "-0.05"},
+		{name: "should scan integer", input: int64(42), expected: "42"},
+		{name: "should scan shortest representation of float", input: 0.1, expected: "0.1"},
+		{name: "should fail to scan NaN", input: "NaN", fail: true},
+		{name: "should fail to scan infinite float", input: math.Inf(1), fail: true},
+		{name: "should fail to scan fraction", input: "1/3", fail: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var n NullNumeric
+
+			err := n.Scan(tc.input)
+			if tc.fail {
+				if !errors.Is(err, ErrInvalidFormat) {
+					t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+				}
+
+				if n.Valid {
+					t.Fatalf("result should be invalid, got: '%v'", n)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if !n.Valid || n.String() != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%s'", n, tc.expected)
+			}
+		})
+	}
+
+	t.Run("successfully scan NULL", func(t *testing.T) {
+		n := NullNumeric{Rat: big.NewRat(1, 1), Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid || n.Rat != nil {
+			t.Fatalf("result should be null, got: '%v' (%v)", n, err)
+		}
+	})
+}
+
+func TestNullNumericValue(t *testing.T) {
+	t.Run("should write exact decimal string", func(t *testing.T) {
+		result, err := NullDecimal(big.NewRat(-1, 8)).Value()
+		if err != nil || result != "-0.125" {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '-0.125'", result, err)
+		}
+	})
+
+	t.Run("should write null", func(t *testing.T) {
+		if result, err := NullDecimal(nil).Value(); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject numbers without decimal representation", func(t *testing.T) {
+		if _, err := NullDecimal(big.NewRat(1, 3)).Value(); !errors.Is(err, ErrInexact) {
+			t.Fatalf("error should wrap ErrInexact, got: '%v'", err)
+		}
+	})
+}
+
+func TestNumericSpec(t *testing.T) {
+	spec := NumericSpec{Precision: 19, Scale: 4}
+
+	testCases := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "should accept value at scale", input: "123456789012345.1234"},
+		{name: "should accept negative value", input: "-999999999999999.9999"},
+		{name: "should reject value beyond scale", input: "1.23456", err: ErrInexact},
+		{name: "should reject value beyond precision", input: "1000000000000000", err: ErrOverflow},
+		{name: "should reject fraction", input: "1/3", err: ErrInexact},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := spec.NullDecimal(rat(t, tc.input))
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("error should wrap '%v', got: '%v'", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil || !n.Valid || n.Rat.Cmp(rat(t, tc.input)) != 0 {
+				t.Fatalf("result mismatch got '%v' (%v), expected: '%s'", n, err, tc.input)
+			}
+		})
+	}
+
+	t.Run("should convert nil to null", func(t *testing.T) {
+		if n, err := spec.NullDecimal(nil); err != nil || n.Valid {
+			t.Fatalf("result should be null, got: '%v' (%v)", n, err)
+		}
+	})
+
+	t.Run("should check scale beyond precision and negative scale", func(t *testing.T) {
+		for _, tc := range []struct {
+			spec  NumericSpec
+			input string
+			err   error
+		}{
+			{spec: NumericSpec{Precision: 2, Scale: 5}, input: "0.00099"},
+			{spec: NumericSpec{Precision: 2, Scale: 5}, input: "0.001", err: ErrOverflow},
+			{spec: NumericSpec{Precision: 2, Scale: 5}, input: "0.000001", err: ErrInexact},
+			{spec: NumericSpec{Precision: 2, Scale: -3}, input: "99000"},
+			{spec: NumericSpec{Precision: 2, Scale: -3}, input: "100000", err: ErrOverflow},
+			{spec: NumericSpec{Precision: 2, Scale: -3}, input: "1500", err: ErrInexact},
+			{spec: NumericSpec{Precision: -1}, input: "1", err: ErrUnsupported},
+		} {
+			if err := tc.spec.Check(rat(t, tc.input)); !errors.Is(err, tc.err) {
+				t.Fatalf("%s.Check(%s) error mismatch; got '%v', expected: '%v'", tc.spec, tc.input, err, tc.err)
+			}
+		}
+	})
+
+	t.Run("should treat nil as zero", func(t *testing.T) {
+		if err := spec.Check(nil); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+	})
+
+	t.Run("should accept any value without precision", func(t *testing.T) {
+		if err := (NumericSpec{}).Check(rat(t, "1e100")); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+	})
+}
+
+func TestNullNumericNilRat(t *testing.T) {
+	n := NullNumeric{Valid: true}
+
+	if v, err := n.Value(); err != nil || v != "0" {
+		t.Fatalf("result mismatch got '%v' (%v), expected: '0'", v, err)
+	}
+
+	if n.String() != "0" {
+		t.Fatalf("result mismatch got '%s', expected: '0'", n.String())
+	}
+
+	if result := UnwrapDecimal(n); result == nil || result.Sign() != 0 {
+		t.Fatalf("result should be zero, got: '%v'", result)
+	}
+
+	if result := UnwrapDecimalFloat(n, 64); result == nil || result.Sign() != 0 {
+		t.Fatalf("result should be zero, got: '%v'", result)
+	}
+}
+
+func TestNumericString(t *testing.T) {
+	t.Run("successfully round trip NUMERIC text", func(t *testing.T) {
+		n, err := NullNumericFromString(sql.NullString{String: "-12.3400", Valid: true})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		s, err := UnwrapNumericString(n)
+		if err != nil || s != (sql.NullString{String: "-12.34", Valid: true}) {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '-12.34'", s, err)
+		}
+	})
+
+	t.Run("successfully convert null", func(t *testing.T) {
+		n, err := NullNumericFromString(sql.NullString{String: "1"})
+		if err != nil || n.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", n, err)
+		}
+
+		if s, err := UnwrapNumericString(n); err != nil || s.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", s, err)
+		}
+	})
+
+	t.Run("should fail to convert NaN and fractions", func(t *testing.T) {
+		if _, err := NullNumericFromString(sql.NullString{String: "NaN", Valid: true}); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+		}
+
+		if _, err := UnwrapNumericString(NullDecimal(big.NewRat(1, 3))); !errors.Is(err, ErrInexact) {
+			t.Fatalf("error should wrap ErrInexact, got: '%v'", err)
+		}
+	})
+}
+
+func TestUnwrapDecimal(t *testing.T) {
+	t.Run("should unwrap a copy", func(t *testing.T) {
+		n := NullDecimal(big.NewRat(3, 2))
+
+		result := UnwrapDecimal(n)
+		result.SetInt64(0)
+
+		if n.Rat.Cmp(big.NewRat(3, 2)) != 0 {
+			t.Fatalf("value should not be modified, got: '%v'", n)
+		}
+	})
+
+	t.Run("should unwrap null to nil", func(t *testing.T) {
+		if result := UnwrapDecimal(NullNumeric{}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapDecimalFloat(NullNumeric{}, 64); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+	})
+
+	t.Run("should round trip big.Float", func(t *testing.T) {
+		f := big.NewFloat(0.375)
+
+		n, err := NullDecimalFromFloat(f)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result := UnwrapDecimalFloat(n, 53); result.Cmp(f) != 0 {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, f)
+		}
+	})
+
+	t.Run("should reject infinite big.Float", func(t *testing.T) {
+		if _, err := NullDecimalFromFloat(new(big.Float).SetInf(false)); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+		}
+	})
+}
@@ -14,154 +14,95 @@ import (
 	"time"
 )
 
-// NullString converts the native go string type to a sql.NullString type.
-func NullString[T string | *string](s T) sql.NullString {
-	nString := sql.NullString{}
-
-	switch v := any(s).(type) {
-	case string:
-		nString.String = v
-		nString.Valid = true
-	case *string:
-		if v != nil {
-			nString.String = *v
-			nString.Valid = true
-		}
+// Null converts a value of any type T to a sql.Null[T] type.
+// The result is always valid, use NullPtr() if the value may be absent.
+func Null[T any](v T) sql.Null[T] {
+	return sql.Null[T]{V: v, Valid: true}
+}
+
+// NullPtr converts a pointer of any type T to a sql.Null[T] type.
+// A nil pointer results in a null value.
+func NullPtr[T any](v *T) sql.Null[T] {
+	if v == nil {
+		return sql.Null[T]{}
 	}
 
-	return nString
+	return Null(*v)
 }
 
-// NullInt64 converts the native go int64 type to a sql.NullInt64 type.
-func NullInt64[T int64 | *int64](i T) sql.NullInt64 {
-	nInt := sql.NullInt64{}
-
-	switch v := any(i).(type) {
-	case int64:
-		nInt.Int64 = v
-		nInt.Valid = true
-	case *int64:
-		if v != nil {
-			nInt.Int64 = *v
-			nInt.Valid = true
-		}
+// nullOf converts either a T or a *T to a sql.Null[T] type.
+// It backs the per-type converters, which constrain their input to exactly those two types.
+func nullOf[T any](v any) sql.Null[T] {
+	switch v := v.(type) {
+	case T:
+		return Null(v)
+	case *T:
+		return NullPtr(v)
 	}
 
-	return nInt
+	return sql.Null[T]{}
+}
+
+// NullString converts the native go string type to a sql.NullString type.
+func NullString[T string | *string](s T) sql.NullString {
+	return ToNullString(nullOf[string](s))
+}
+
+// NullInt64 converts the native go int64 type to a sql.NullInt64 type.
+func NullInt64[T int64 | *int64](i T) sql.NullInt64 {
+	return ToNullInt64(nullOf[int64](i))
 }
 
 // NullInt32 converts the native go int32 type to a sql.NullInt32 type.
 func NullInt32[T int32 | *int32](i T) sql.NullInt32 {
-	nInt := sql.NullInt32{}
-
-	switch v := any(i).(type) {
-	case int32:
-		nInt.Int32 = v
-		nInt.Valid = true
-	case *int32:
-		if v != nil {
-			nInt.Int32 = *v
-			nInt.Valid = true
-		}
-	}
-
-	return nInt
+	return ToNullInt32(nullOf[int32](i))
 }
 
 // NullInt16 converts the native go int16 type to a sql.NullInt16 type.
 func NullInt16[T int16 | *int16](i T) sql.NullInt16 {
-	nInt := sql.NullInt16{}
-
-	switch v := any(i).(type) {
-	case int16:
-		nInt.Int16 = v
-		nInt.Valid = true
-	case *int16:
-		if v != nil {
-			nInt.Int16 = *v
-			nInt.Valid = true
-		}
-	}
-
-	return nInt
+	return ToNullInt16(nullOf[int16](i))
 }
 
 // ToNullByte converts the native go byte type to a sql.NullByte type.
 func NullByte[T byte | *byte](b T) sql.NullByte {
-	nByte := sql.NullByte{}
-
-	switch v := any(b).(type) {
-	case byte:
-		nByte.Byte = v
-		nByte.Valid = true
-	case *byte:
-		if v != nil {
-			nByte.Byte = *v
-			nByte.Valid = true
-		}
-	}
-
-	return nByte
+	return ToNullByte(nullOf[byte](b))
 }
 
 // NullFloat64 converts the native go float64 type to a sql.NullFloat64 type.
 func NullFloat64[T float64 | *float64](f T) sql.NullFloat64 {
-	nFloat := sql.NullFloat64{}
-
-	switch v := any(f).(type) {
-	case float64:
-		nFloat.Float64 = v
-		nFloat.Valid = true
-	case *float64:
-		if v != nil {
-			nFloat.Float64 = *v
-			nFloat.Valid = true
-		}
-	}
+	return ToNullFloat64(nullOf[float64](f))
+}
 
-	return nFloat
+// NullFloat32 converts the native go float32 type to a sql.Null[float32] type.
+// There is no sql.NullFloat32, use NullReal for REAL columns generated as sql.NullFloat64.
+func NullFloat32[T float32 | *float32](f T) sql.Null[float32] {
+	return nullOf[float32](f)
+}
+
+// NullReal converts the native go float32 type to a sql.NullFloat64 type, as generated for REAL columns.
+// Every float32 is exactly representable as a float64.
+func NullReal[T float32 | *float32](f T) sql.NullFloat64 {
+	n := nullOf[float32](f)
+
+	return sql.NullFloat64{Float64: float64(n.V), Valid: n.Valid}
 }
 
 // NullBoolean converts the native go boolean type to a sql.NullBool type.
 func NullBoolean[T bool | *bool](b T) sql.NullBool {
-	nBool := sql.NullBool{}
-
-	switch v := any(b).(type) {
-	case bool:
-		nBool.Bool = v
-		nBool.Valid = true
-	case *bool:
-		if v != nil {
-			nBool.Bool = *v
-			nBool.Valid = true
-		}
-	}
-
-	return nBool
+	return ToNullBool(nullOf[bool](b))
 }
 
 // NullTime converts an time pointer to a sql NullTime type.
 // This function will treats the Zero time as valid.
 func NullTime[T time.Time | *time.Time](t T) sql.NullTime {
-	nTime := sql.NullTime{}
-
-	switch v := any(t).(type) {
-	case time.Time:
-		nTime.Time = v
-		nTime.Valid = true
-	case *time.Time:
-		if v != nil {
-			nTime.Time = *v
-			nTime.Valid = true
-		}
-	}
-
-	return nTime
+	return ToNullTime(nullOf[time.Time](t))
 }
 
 // Serial is a notational convenience for creating unique identifier columns.
 // It is an auto-incrementing integer starting from zero.
 // https://www.postgresql.org/docs/current/datatype-numeric.html#DATATYPE-SERIAL
+//
+// Deprecated: Use ParseSerial, which returns a *ConversionError, or the SerialID type.
 func Serial(in string) (uint64, error) {
 	out, err := strconv.ParseUint(in, 10, 64)
 	if err != nil {
@@ -8,7 +8,9 @@
 package sqlmap
 
 import (
+	"bytes"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"strings"
 	"testing"
@@ -271,6 +273,35 @@ func TestNullFloat64(t *testing.T) {
 	})
 }
 
+func TestNullFloat32(t *testing.T) {
+	t.Run("successfully handle non-pointer float32 values", func(t *testing.T) {
+		var v float32 = 1.5
+
+		compareNull(t, &v, NullFloat32(v))
+	})
+
+	t.Run("successfully handle null float32 pointers", func(t *testing.T) {
+		var v *float32
+
+		compareNull(t, v, NullFloat32(v))
+	})
+}
+
+func TestNullReal(t *testing.T) {
+	t.Run("successfully widen float32 exactly", func(t *testing.T) {
+		v := float32(0.1)
+		expected := float64(v)
+
+		compareFloat64(t, &expected, NullReal(&v))
+	})
+
+	t.Run("successfully handle null float32 pointers", func(t *testing.T) {
+		var v *float32
+
+		compareFloat64(t, nil, NullReal(v))
+	})
+}
+
 func TestNullBoolean(t *testing.T) {
 	t.Run("successfully handle non-pointer bool values ", func(t *testing.T) {
 		v := true
@@ -366,3 +397,126 @@ func TestSerial(t *testing.T) {
 		})
 	}
 }
+
+// compareNull ensures the pointer and sql.Null[T] are equal.
+func compareNull[T comparable](t *testing.T, expected *T, actual sql.Null[T]) {
+	t.Helper()
+
+	if expected == nil {
+		if actual.Valid {
+			t.Fatalf("expected is nil, actual should not be valid")
+		}
+
+		return
+	}
+
+	if *expected != actual.V {
+		t.Fatalf("result: '%v' does not equal expected: '%v'", actual.V, *expected)
+	}
+}
+
+// accountID is a domain type used to ensure the generic converters work with user defined types.
+type accountID uint32
+
+func TestNull(t *testing.T) {
+	t.Run("successfully handle float32 values", func(t *testing.T) {
+		var v float32 = 1.5
+
+		compareNull(t, &v, Null(v))
+	})
+
+	t.Run("successfully handle uint32 values", func(t *testing.T) {
+		var v uint32 = 42
+
+		compareNull(t, &v, Null(v))
+	})
+
+	t.Run("successfully handle int8 values", func(t *testing.T) {
+		var v int8 = -8
+
+		compareNull(t, &v, Null(v))
+	})
+
+	t.Run("successfully handle domain type values", func(t *testing.T) {
+		v := accountID(7)
+
+		compareNull(t, &v, Null(v))
+	})
+
+	t.Run("successfully handle byte slice values", func(t *testing.T) {
+		v := []byte("foo")
+
+		result := Null(v)
+
+		if !result.Valid {
+			t.Fatalf("result should be valid")
+		}
+
+		if !bytes.Equal(v, result.V) {
+			t.Fatalf("result: '%v' does not equal expected: '%v'", result.V, v)
+		}
+	})
+}
+
+func TestNullPtr(t *testing.T) {
+	t.Run("successfully handle null float32 pointers", func(t *testing.T) {
+		var v *float32
+
+		compareNull(t, v, NullPtr(v))
+	})
+
+	t.Run("successfully handle non-null float32 pointers", func(t *testing.T) {
+		var v float32 = 1.5
+
+		compareNull(t, &v, NullPtr(&v))
+	})
+
+	t.Run("successfully handle null domain type pointers", func(t *testing.T) {
+		var v *accountID
+
+		compareNull(t, v, NullPtr(v))
+	})
+
+	t.Run("successfully handle non-null domain type pointers", func(t *testing.T) {
+		v := accountID(7)
+
+		compareNull(t, &v, NullPtr(&v))
+	})
+}
+
+func TestNullValid(t *testing.T) {
+	var m Mapper
+
+	testCases := []struct {
+		name   string
+		result interface{ Value() (driver.Value, error) }
+	}{
+		{name: "NullString", result: NullString(ptr(""))},
+		{name: "NullInt64", result: NullInt64(ptr[int64](0))},
+		{name: "NullInt32", result: NullInt32(ptr[int32](0))},
+		{name: "NullInt16", result: NullInt16(ptr[int16](0))},
+		{name: "NullByte", result: NullByte(ptr[byte](0))},
+		{name: "NullFloat64", result: NullFloat64(ptr[float64](0))},
+		{name: "NullReal", result: NullReal(ptr[float32](0))},
+		{name: "NullBoolean", result: NullBoolean(ptr(false))},
+		{name: "NullTime", result: NullTime(&time.Time{})},
+		{name: "Mapper.NullString", result: m.NullString(ptr(""))},
+		{name: "Mapper.NullInt64", result: m.NullInt64(ptr[int64](0))},
+		{name: "Mapper.NullFloat64", result: m.NullFloat64(ptr[float64](0))},
+		{name: "Mapper.NullBoolean", result: m.NullBoolean(ptr(false))},
+		{name: "Mapper.NullTime", result: m.NullTime(&time.Time{})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" should be valid for non-nil zero values", func(t *testing.T) {
+			v, err := tc.result.Value()
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if v == nil {
+				t.Fatalf("result should be valid, got null")
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrOverflow is returned when a value does not fit in the target type.
+	ErrOverflow = errors.New("sqlmap: value out of range")
+
+	// ErrInexact is returned when a value cannot be converted without rounding.
+	ErrInexact = errors.New("sqlmap: value cannot be represented exactly")
+
+	// ErrNonFinite is returned when NaN or an infinite number is rejected by a NonFinite policy.
+	ErrNonFinite = errors.New("sqlmap: value is not a finite number")
+
+	// ErrInvalidFormat is returned when a string is not a valid representation of the target type.
+	ErrInvalidFormat = errors.New("sqlmap: invalid format")
+
+	// ErrInvalidTimestamp is returned when a timestamp is outside the range supported by the target type.
+	ErrInvalidTimestamp = errors.New("sqlmap: invalid timestamp")
+
+	// ErrNoFixedDuration is returned when an interval holding months is converted to a fixed duration.
+	ErrNoFixedDuration = errors.New("sqlmap: interval has no fixed duration")
+
+	// ErrNull is returned when NULL is converted to a type that cannot represent it.
+	ErrNull = errors.New("sqlmap: unexpected null")
+
+	// ErrUnsupported is returned when there is no conversion between the source and target type.
+	ErrUnsupported = errors.New("sqlmap: unsupported conversion")
+
+	// ErrNoMatchingField is returned by MapModel when a destination field has no matching source field.
+	ErrNoMatchingField = errors.New("sqlmap: no matching field")
+
+	// ErrTooMany is returned when a list holds more elements than allowed.
+	ErrTooMany = errors.New("sqlmap: too many elements")
+)
+
+// ConversionError describes a value that could not be converted.
+// Err wraps one of the sentinel errors of the package, and the underlying error if any, so both can be tested with errors.Is and errors.As.
+//
+//	if _, err := sqlmap.ParseSerial(in); errors.Is(err, sqlmap.ErrInvalidFormat) {
+//		return status.Error(codes.InvalidArgument, err.Error())
+//	}
+type ConversionError struct {
+	// Field is the path of the field within the destination struct, e.g. "Address.Zip".
+	// It is only set by Map and MapModel.
+	Field string
+	// Source is the type of the converted value, empty if there is no source value.
+	Source string
+	// Target is the type the value was converted to.
+	Target string
+	// Value is the converted value, nil if the error is not specific to a value.
+	Value any
+	// Err is the cause of the failure.
+	Err error
+}
+
+// conversionError returns a ConversionError for the value, wrapping the sentinel error kind and the optional cause.
+func conversionError(value any, target string, kind, cause error) *ConversionError {
+	err := kind
+	if cause != nil {
+		err = fmt.Errorf("%w: %w", kind, cause)
+	}
+
+	return &ConversionError{Source: fmt.Sprintf("%T", value), Target: target, Value: value, Err: err}
+}
+
+// Error implements the error interface.
+func (e *ConversionError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("sqlmap: ")
+
+	if e.Field != "" {
+		fmt.Fprintf(&b, "field %s: ", e.Field)
+	}
+
+	switch {
+	case e.Source == "":
+		// There is no source value, e.g. the destination field has no matching source field.
+	case e.Value != nil:
+		fmt.Fprintf(&b, "cannot convert %s '%v' to %s: ", e.Source, e.Value, e.Target)
+	default:
+		fmt.Fprintf(&b, "cannot convert %s to %s: ", e.Source, e.Target)
+	}
+
+	if e.Err != nil {
+		b.WriteString(strings.TrimPrefix(e.Err.Error(), "sqlmap: "))
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,94 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"math"
+)
+
+// NonFinite is the policy applied to NaN and infinite floating point numbers.
+// Postgres stores them, while MySQL rejects them and JSON cannot represent them.
+// The package level functions pass them through, like NonFiniteAllow.
+//
+//	var policy = sqlmap.NonFiniteReject
+//
+//	ratio, err := policy.NullReal(req.Ratio)
+type NonFinite int
+
+const (
+	// NonFiniteAllow passes NaN and infinite numbers through unchanged.
+	NonFiniteAllow NonFinite = iota
+	// NonFiniteNull converts NaN and infinite numbers to null.
+	NonFiniteNull
+	// NonFiniteReject returns a *ConversionError wrapping ErrNonFinite for NaN and infinite numbers.
+	NonFiniteReject
+)
+
+// NullFloat64 converts the float64 pointer to a sql.NullFloat64 type, applying the policy.
+func (p NonFinite) NullFloat64(f *float64) (sql.NullFloat64, error) {
+	n, err := applyNonFinite(p, NullPtr(f), "sql.NullFloat64")
+
+	return ToNullFloat64(n), err
+}
+
+// NullFloat32 converts the float32 pointer to a sql.Null[float32] type, applying the policy.
+func (p NonFinite) NullFloat32(f *float32) (sql.Null[float32], error) {
+	return applyNonFinite(p, NullPtr(f), "sql.Null[float32]")
+}
+
+// NullReal converts the float32 pointer to the sql.NullFloat64 type of a REAL column, applying the policy.
+func (p NonFinite) NullReal(f *float32) (sql.NullFloat64, error) {
+	n, err := applyNonFinite(p, NullPtr(f), "sql.NullFloat64")
+
+	return NullReal(Unwrap(n)), err
+}
+
+// UnwrapFloat64 unwraps the sql.NullFloat64 to a float64 pointer, applying the policy.
+// Null and, with NonFiniteNull, NaN and infinite numbers are unwrapped to nil.
+func (p NonFinite) UnwrapFloat64(f sql.NullFloat64) (*float64, error) {
+	n, err := applyNonFinite(p, FromNullFloat64(f), "float64")
+
+	return Unwrap(n), err
+}
+
+// UnwrapFloat32 unwraps the sql.Null[float32] to a float32 pointer, applying the policy.
+// Null and, with NonFiniteNull, NaN and infinite numbers are unwrapped to nil.
+func (p NonFinite) UnwrapFloat32(f sql.Null[float32]) (*float32, error) {
+	n, err := applyNonFinite(p, f, "float32")
+
+	return Unwrap(n), err
+}
+
+// UnwrapReal unwraps the sql.NullFloat64 of a REAL column to a float32 pointer, applying the policy.
+// Finite values outside the range of a float32 return an error wrapping ErrOverflow.
+func (p NonFinite) UnwrapReal(f sql.NullFloat64) (*float32, error) {
+	n, err := applyNonFinite(p, FromNullFloat64(f), "float32")
+	if err != nil {
+		return nil, err
+	}
+
+	return UnwrapReal(ToNullFloat64(n))
+}
+
+// applyNonFinite applies the policy to the floating point number.
+func applyNonFinite[T float32 | float64](p NonFinite, n sql.Null[T], target string) (sql.Null[T], error) {
+	if !n.Valid || p == NonFiniteAllow {
+		return n, nil
+	}
+
+	if f := float64(n.V); !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return n, nil
+	}
+
+	if p == NonFiniteNull {
+		return sql.Null[T]{}, nil
+	}
+
+	return sql.Null[T]{}, conversionError(n.V, target, ErrNonFinite, nil)
+}
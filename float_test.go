@@ -0,0 +1,91 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNonFinite(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy NonFinite
+		input  float64
+		valid  bool
+		err    error
+	}{
+		{name: "should allow NaN", policy: NonFiniteAllow, input: math.NaN(), valid: true},
+		{name: "should allow infinity", policy: NonFiniteAllow, input: math.Inf(-1), valid: true},
+		{name: "should convert NaN to null", policy: NonFiniteNull, input: math.NaN()},
+		{name: "should convert infinity to null", policy: NonFiniteNull, input: math.Inf(1)},
+		{name: "should reject NaN", policy: NonFiniteReject, input: math.NaN(), err: ErrNonFinite},
+		{name: "should reject infinity", policy: NonFiniteReject, input: math.Inf(-1), err: ErrNonFinite},
+		{name: "should keep finite numbers", policy: NonFiniteReject, input: 1.5, valid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f32 := float32(tc.input)
+
+			check := func(valid bool, err error) {
+				t.Helper()
+
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("error mismatch; got '%v', expected: '%v'", err, tc.err)
+				}
+
+				if valid != tc.valid {
+					t.Fatalf("validity mismatch got '%v', expected: '%v'", valid, tc.valid)
+				}
+			}
+
+			n64, err := tc.policy.NullFloat64(&tc.input)
+			check(n64.Valid, err)
+
+			n32, err := tc.policy.NullFloat32(&f32)
+			check(n32.Valid, err)
+
+			nReal, err := tc.policy.NullReal(&f32)
+			check(nReal.Valid, err)
+
+			p64, err := tc.policy.UnwrapFloat64(sql.NullFloat64{Float64: tc.input, Valid: true})
+			check(p64 != nil, err)
+
+			p32, err := tc.policy.UnwrapFloat32(sql.Null[float32]{V: f32, Valid: true})
+			check(p32 != nil, err)
+
+			pReal, err := tc.policy.UnwrapReal(sql.NullFloat64{Float64: tc.input, Valid: true})
+			check(pReal != nil, err)
+		})
+	}
+
+	t.Run("successfully pass null through", func(t *testing.T) {
+		n, err := NonFiniteReject.NullFloat64(nil)
+		if err != nil || n.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", n, err)
+		}
+	})
+
+	t.Run("should report the rejected value", func(t *testing.T) {
+		_, err := NonFiniteReject.UnwrapFloat64(sql.NullFloat64{Float64: math.Inf(1), Valid: true})
+
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) || convErr.Source != "float64" || convErr.Target != "float64" {
+			t.Fatalf("error should be a ConversionError, got: '%+v'", convErr)
+		}
+	})
+
+	t.Run("should reject values outside float32 range", func(t *testing.T) {
+		if _, err := NonFiniteNull.UnwrapReal(sql.NullFloat64{Float64: math.MaxFloat64, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
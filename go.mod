@@ -1,8 +1,9 @@
 module github.com/justinsimmons/sqlmap
 
-go 1.21.6
+go 1.22
 
 require (
 	github.com/google/uuid v1.5.0
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1
 	google.golang.org/protobuf v1.32.0
 )
@@ -0,0 +1,118 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Integer is the set of go integer types, including types defined on top of them.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// NullInteger is the set of sql null integer types.
+type NullInteger interface {
+	sql.NullInt64 | sql.NullInt32 | sql.NullInt16
+}
+
+// convertInteger converts the integer to the type T.
+// It returns an error wrapping ErrOverflow instead of silently truncating values that do not fit in T.
+func convertInteger[T, S Integer](v S) (T, error) {
+	t := T(v)
+
+	// The conversion is lossless if it round trips and keeps the sign.
+	if S(t) != v || (t < 0) != (v < 0) {
+		return 0, conversionError(v, fmt.Sprintf("%T", t), ErrOverflow, nil)
+	}
+
+	return t, nil
+}
+
+// convertIntegerPtr converts the integer pointer to a sql.Null[T] type, range checking the value.
+func convertIntegerPtr[T, S Integer](v *S) (sql.Null[T], error) {
+	if v == nil {
+		return sql.Null[T]{}, nil
+	}
+
+	t, err := convertInteger[T](*v)
+	if err != nil {
+		return sql.Null[T]{}, err
+	}
+
+	return Null(t), nil
+}
+
+// NullInt64Of converts any go integer type to a sql.NullInt64 type.
+// Unsigned values above math.MaxInt64 return an error wrapping ErrOverflow.
+func NullInt64Of[T Integer](i T) (sql.NullInt64, error) {
+	return NullInt64OfPtr(&i)
+}
+
+// NullInt64OfPtr converts a pointer to any go integer type to a sql.NullInt64 type.
+// Unsigned values above math.MaxInt64 return an error wrapping ErrOverflow.
+func NullInt64OfPtr[T Integer](i *T) (sql.NullInt64, error) {
+	n, err := convertIntegerPtr[int64](i)
+
+	return ToNullInt64(n), err
+}
+
+// NullInt32Of converts any go integer type to a sql.NullInt32 type.
+// Values outside the range of an int32 return an error wrapping ErrOverflow.
+func NullInt32Of[T Integer](i T) (sql.NullInt32, error) {
+	return NullInt32OfPtr(&i)
+}
+
+// NullInt32OfPtr converts a pointer to any go integer type to a sql.NullInt32 type.
+// Values outside the range of an int32 return an error wrapping ErrOverflow.
+func NullInt32OfPtr[T Integer](i *T) (sql.NullInt32, error) {
+	n, err := convertIntegerPtr[int32](i)
+
+	return ToNullInt32(n), err
+}
+
+// NullInt16Of converts any go integer type to a sql.NullInt16 type.
+// Values outside the range of an int16 return an error wrapping ErrOverflow.
+func NullInt16Of[T Integer](i T) (sql.NullInt16, error) {
+	return NullInt16OfPtr(&i)
+}
+
+// NullInt16OfPtr converts a pointer to any go integer type to a sql.NullInt16 type.
+// Values outside the range of an int16 return an error wrapping ErrOverflow.
+func NullInt16OfPtr[T Integer](i *T) (sql.NullInt16, error) {
+	n, err := convertIntegerPtr[int16](i)
+
+	return ToNullInt16(n), err
+}
+
+// UnwrapInteger unwraps any sql null integer type to a pointer of the integer type T.
+// Values outside the range of T return an error wrapping ErrOverflow.
+//
+//	count, err := sqlmap.UnwrapInteger[uint32](row.Count)
+func UnwrapInteger[T Integer, N NullInteger](n N) (*T, error) {
+	var (
+		nt  sql.Null[T]
+		err error
+	)
+
+	switch v := any(n).(type) {
+	case sql.NullInt64:
+		nt, err = convertIntegerPtr[T](Unwrap(FromNullInt64(v)))
+	case sql.NullInt32:
+		nt, err = convertIntegerPtr[T](Unwrap(FromNullInt32(v)))
+	case sql.NullInt16:
+		nt, err = convertIntegerPtr[T](Unwrap(FromNullInt16(v)))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Unwrap(nt), nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNullInt64Of(t *testing.T) {
+	t.Run("successfully convert int values", func(t *testing.T) {
+		result, err := NullInt64Of(42)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt64(t, ptr[int64](42), result)
+	})
+
+	t.Run("successfully convert math.MaxInt64 as uint64", func(t *testing.T) {
+		result, err := NullInt64Of(uint64(math.MaxInt64))
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt64(t, ptr[int64](math.MaxInt64), result)
+	})
+
+	t.Run("should fail to convert uint64 above math.MaxInt64", func(t *testing.T) {
+		if _, err := NullInt64Of(uint64(math.MaxInt64) + 1); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+
+	t.Run("successfully handle null pointers", func(t *testing.T) {
+		var v *uint
+
+		result, err := NullInt64OfPtr(v)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt64(t, nil, result)
+	})
+}
+
+func TestNullInt32Of(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    *int
+		expected *int32
+		overflow bool
+	}{
+		{name: "successfully convert int values", input: ptr(42), expected: ptr[int32](42)},
+		{name: "successfully convert math.MinInt32", input: ptr(math.MinInt32), expected: ptr[int32](math.MinInt32)},
+		{name: "successfully convert null pointers", input: nil, expected: nil},
+		{name: "should fail to convert values above math.MaxInt32", input: ptr(math.MaxInt32 + 1), overflow: true},
+		{name: "should fail to convert values below math.MinInt32", input: ptr(math.MinInt32 - 1), overflow: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NullInt32OfPtr(tc.input)
+			if tc.overflow {
+				if !errors.Is(err, ErrOverflow) {
+					t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+				}
+
+				if result.Valid {
+					t.Fatalf("result should not be valid on error, got: '%v'", result)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			compareInt32(t, tc.expected, result)
+		})
+	}
+}
+
+func TestNullInt16Of(t *testing.T) {
+	t.Run("successfully convert uint32 values", func(t *testing.T) {
+		result, err := NullInt16Of(uint32(math.MaxInt16))
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt16(t, ptr[int16](math.MaxInt16), result)
+	})
+
+	t.Run("should fail to convert values above math.MaxInt16", func(t *testing.T) {
+		if _, err := NullInt16Of(uint32(math.MaxInt16 + 1)); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
+
+func TestUnwrapInteger(t *testing.T) {
+	t.Run("successfully unwrap sql.NullInt32 to uint32", func(t *testing.T) {
+		result, err := UnwrapInteger[uint32](sql.NullInt32{Int32: 42, Valid: true})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result == nil || *result != 42 {
+			t.Fatalf("result mismatch got '%v', expected: '%d'", result, 42)
+		}
+	})
+
+	t.Run("successfully unwrap null sql.NullInt64", func(t *testing.T) {
+		result, err := UnwrapInteger[int](sql.NullInt64{Int64: 42, Valid: false})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result != nil {
+			t.Fatalf("result should be null, got: '%d'", *result)
+		}
+	})
+
+	t.Run("should fail to unwrap negative sql.NullInt16 to uint", func(t *testing.T) {
+		if _, err := UnwrapInteger[uint](sql.NullInt16{Int16: -1, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+
+	t.Run("should fail to unwrap sql.NullInt64 to int8", func(t *testing.T) {
+		if _, err := UnwrapInteger[int8](sql.NullInt64{Int64: 128, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+// Package gen selects the sqlmap functions used by generated mapping code.
+// Types are identified by their fully qualified name as printed by types.TypeString with a nil qualifier,
+// e.g. "*string", "database/sql.NullString" or "database/sql.Null[float32]".
+package gen
+
+import "strings"
+
+// ImportPath is the import path of the sqlmap package called by generated code.
+const ImportPath = "github.com/justinsimmons/sqlmap"
+
+const (
+	nullTime   = "database/sql.NullTime"
+	nullUUID   = "github.com/google/uuid.NullUUID"
+	uuidType   = "github.com/google/uuid.UUID"
+	timestamp  = "*google.golang.org/protobuf/types/known/timestamppb.Timestamp"
+	nullOpen   = "database/sql.Null["
+	wrappers   = "*google.golang.org/protobuf/types/known/wrapperspb."
+	nullBytes  = ImportPath + ".NullBytes"
+	numeric    = ImportPath + ".NullNumeric"
+	nullUint64 = ImportPath + ".NullUint64"
+)
+
+// pair identifies a conversion from one type to another.
+type pair struct {
+	src string
+	dst string
+}
+
+// funcs holds the sqlmap function converting each pair of types.
+var funcs = map[pair]string{}
+
+// fallible holds the functions of funcs that also return an error.
+var fallible = map[string]bool{}
+
+func init() {
+	scalars := []struct {
+		typ    string
+		null   string
+		suffix string
+	}{
+		{"string", "database/sql.NullString", "String"},
+		{"int64", "database/sql.NullInt64", "Int64"},
+		{"int32", "database/sql.NullInt32", "Int32"},
+		{"int16", "database/sql.NullInt16", "Int16"},
+		{"uint8", "database/sql.NullByte", "Byte"},
+		{"float64", "database/sql.NullFloat64", "Float64"},
+		{"bool", "database/sql.NullBool", "Boolean"},
+	}
+
+	for _, s := range scalars {
+		funcs[pair{s.typ, s.null}] = "Null" + s.suffix
+		funcs[pair{"*" + s.typ, s.null}] = "Null" + s.suffix
+		funcs[pair{s.null, "*" + s.typ}] = "Unwrap" + s.suffix
+		funcs[pair{s.null, s.typ}] = "Unwrap" + s.suffix + "OrZero"
+	}
+
+	funcs[pair{"time.Time", nullTime}] = "NullTime"
+	funcs[pair{"*time.Time", nullTime}] = "NullTime"
+	funcs[pair{timestamp, nullTime}] = "NullTimeFromTimestamp"
+	funcs[pair{nullTime, "time.Time"}] = "UnwrapTime"
+	funcs[pair{nullTime, "*time.Time"}] = "UnwrapTimePtr"
+	funcs[pair{nullTime, timestamp}] = "UnwrapTimestamp"
+
+	wrapped := []struct {
+		wrapper string
+		null    string
+		from    string
+		unwrap  string
+	}{
+		{"StringValue", "database/sql.NullString", "NullStringFromWrapper", "UnwrapStringValue"},
+		{"Int64Value", "database/sql.NullInt64", "NullInt64FromWrapper", "UnwrapInt64Value"},
+		{"Int32Value", "database/sql.NullInt32", "NullInt32FromWrapper", "UnwrapInt32Value"},
+		{"DoubleValue", "database/sql.NullFloat64", "NullFloat64FromWrapper", "UnwrapDoubleValue"},
+		{"BoolValue", "database/sql.NullBool", "NullBooleanFromWrapper", "UnwrapBoolValue"},
+		{"UInt64Value", nullUint64, "NullUint64FromWrapper", "UnwrapUInt64Value"},
+		{"BytesValue", "[]uint8", "BytesFromWrapper", "UnwrapBytesValue"},
+	}
+
+	for _, w := range wrapped {
+		funcs[pair{wrappers + w.wrapper, w.null}] = w.from
+		funcs[pair{w.null, wrappers + w.wrapper}] = w.unwrap
+	}
+
+	funcs[pair{wrappers + "FloatValue", "database/sql.NullFloat64"}] = "NullFloat64FromWrapper"
+	funcs[pair{wrappers + "FloatValue", "database/sql.Null[float32]"}] = "NullFloat32FromWrapper"
+	funcs[pair{"database/sql.Null[float32]", wrappers + "FloatValue"}] = "UnwrapFloat32Value"
+	funcs[pair{"float32", "database/sql.NullFloat64"}] = "NullReal"
+	funcs[pair{"*float32", "database/sql.NullFloat64"}] = "NullReal"
+	funcs[pair{"database/sql.NullInt16", wrappers + "Int32Value"}] = "UnwrapInt32Value"
+
+	funcs[pair{wrappers + "BytesValue", nullBytes}] = "NullBytesFromWrapper"
+	funcs[pair{nullBytes, wrappers + "BytesValue"}] = "UnwrapNullBytesValue"
+	funcs[pair{"[]uint8", nullBytes}] = "NullBytesOf"
+	funcs[pair{"*[]uint8", nullBytes}] = "NullBytesOf"
+	funcs[pair{nullBytes, "[]uint8"}] = "UnwrapBytes"
+	funcs[pair{nullBytes, "*[]uint8"}] = "UnwrapBytesPtr"
+
+	funcs[pair{"database/sql.NullString", numeric}] = "NullNumericFromString"
+	funcs[pair{numeric, "database/sql.NullString"}] = "UnwrapNumericString"
+	fallible["NullNumericFromString"] = true
+	fallible["UnwrapNumericString"] = true
+
+	funcs[pair{"uint64", nullUint64}] = "NullUint64From"
+	funcs[pair{"*uint64", nullUint64}] = "NullUint64From"
+	funcs[pair{nullUint64, "*uint64"}] = "UnwrapUint64"
+	funcs[pair{nullUint64, "uint64"}] = "UnwrapUint64OrZero"
+
+	funcs[pair{uuidType, nullUUID}] = "NullUUID"
+	funcs[pair{"*" + uuidType, nullUUID}] = "NullUUID"
+	funcs[pair{nullUUID, uuidType}] = "UnwrapUUID"
+	funcs[pair{nullUUID, "*" + uuidType}] = "UnwrapUUIDPtr"
+}
+
+// Func returns the name of the sqlmap function converting a value of the src type into the dst type.
+// An empty name with ok set means the value is assigned as is.
+func Func(dst, src string) (name string, ok bool) {
+	dst, src = normalize(dst), normalize(src)
+
+	if dst == src {
+		return "", true
+	}
+
+	if name, ok := funcs[pair{src, dst}]; ok {
+		return name, true
+	}
+
+	if elem, ok := genericElem(dst); ok {
+		switch src {
+		case elem:
+			return "Null", true
+		case "*" + elem:
+			return "NullPtr", true
+		}
+	}
+
+	if elem, ok := genericElem(src); ok {
+		switch dst {
+		case elem:
+			return "UnwrapOrZero", true
+		case "*" + elem:
+			return "Unwrap", true
+		}
+	}
+
+	return "", false
+}
+
+// Fallible reports whether the function returned by Func also returns an error, which the generated code must handle.
+func Fallible(name string) bool {
+	return fallible[name]
+}
+
+// genericElem returns the type argument of a sql.Null[T] instantiation.
+func genericElem(t string) (string, bool) {
+	if !strings.HasPrefix(t, nullOpen) || !strings.HasSuffix(t, "]") {
+		return "", false
+	}
+
+	return t[len(nullOpen) : len(t)-1], true
+}
+
+// normalize spells the byte alias as uint8, which is how the conversions are keyed.
+func normalize(t string) string {
+	switch t {
+	case "byte":
+		return "uint8"
+	case "*byte":
+		return "*uint8"
+	case "[]byte":
+		return "[]uint8"
+	case "*[]byte":
+		return "*[]uint8"
+	}
+
+	return strings.ReplaceAll(t, "[byte]", "[uint8]")
+}
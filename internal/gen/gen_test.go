@@ -0,0 +1,68 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package gen
+
+import "testing"
+
+func TestFunc(t *testing.T) {
+	testCases := []struct {
+		dst      string
+		src      string
+		expected string
+		ok       bool
+	}{
+		{dst: "string", src: "string", expected: "", ok: true},
+		{dst: "database/sql.NullString", src: "*string", expected: "NullString", ok: true},
+		{dst: "database/sql.NullString", src: "string", expected: "NullString", ok: true},
+		{dst: "*string", src: "database/sql.NullString", expected: "UnwrapString", ok: true},
+		{dst: "string", src: "database/sql.NullString", expected: "UnwrapStringOrZero", ok: true},
+		{dst: "database/sql.NullByte", src: "*byte", expected: "NullByte", ok: true},
+		{dst: "database/sql.NullTime", src: timestamp, expected: "NullTimeFromTimestamp", ok: true},
+		{dst: timestamp, src: "database/sql.NullTime", expected: "UnwrapTimestamp", ok: true},
+		{dst: "database/sql.NullInt64", src: wrappers + "Int64Value", expected: "NullInt64FromWrapper", ok: true},
+		{dst: "database/sql.NullFloat64", src: wrappers + "FloatValue", expected: "NullFloat64FromWrapper", ok: true},
+		{dst: wrappers + "Int32Value", src: "database/sql.NullInt16", expected: "UnwrapInt32Value", ok: true},
+		{dst: wrappers + "FloatValue", src: "database/sql.Null[float32]", expected: "UnwrapFloat32Value", ok: true},
+		{dst: "database/sql.NullFloat64", src: "*float32", expected: "NullReal", ok: true},
+		{dst: wrappers + "BytesValue", src: "[]byte", expected: "UnwrapBytesValue", ok: true},
+		{dst: wrappers + "BytesValue", src: nullBytes, expected: "UnwrapNullBytesValue", ok: true},
+		{dst: nullBytes, src: "*[]byte", expected: "NullBytesOf", ok: true},
+		{dst: "[]byte", src: nullBytes, expected: "UnwrapBytes", ok: true},
+		{dst: "*github.com/google/uuid.UUID", src: "github.com/google/uuid.NullUUID", expected: "UnwrapUUIDPtr", ok: true},
+		{dst: "database/sql.Null[float32]", src: "float32", expected: "Null", ok: true},
+		{dst: "database/sql.Null[float32]", src: "*float32", expected: "NullPtr", ok: true},
+		{dst: "*float32", src: "database/sql.Null[float32]", expected: "Unwrap", ok: true},
+		{dst: "float32", src: "database/sql.Null[float32]", expected: "UnwrapOrZero", ok: true},
+		{dst: numeric, src: "database/sql.NullString", expected: "NullNumericFromString", ok: true},
+		{dst: "database/sql.NullString", src: numeric, expected: "UnwrapNumericString", ok: true},
+		{dst: nullUint64, src: "*uint64", expected: "NullUint64From", ok: true},
+		{dst: "uint64", src: nullUint64, expected: "UnwrapUint64OrZero", ok: true},
+		{dst: "database/sql.NullString", src: "int", expected: "", ok: false},
+		{dst: "database/sql.Null[float32]", src: "float64", expected: "", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.src+" to "+tc.dst, func(t *testing.T) {
+			result, ok := Func(tc.dst, tc.src)
+
+			if ok != tc.ok || result != tc.expected {
+				t.Fatalf("result mismatch got '%s' (%v), expected: '%s' (%v)", result, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+}
+
+func TestFallible(t *testing.T) {
+	if !Fallible("NullNumericFromString") || !Fallible("UnwrapNumericString") {
+		t.Fatalf("numeric string conversions should be fallible")
+	}
+
+	if Fallible("NullString") || Fallible("") {
+		t.Fatalf("infallible conversions should not be fallible")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The functions in this file convert between the legacy sql.NullX types and the generic sql.Null[T] type.
+// They allow code generated before and after the switch to sql.Null[T] to be used side by side.
+
+// FromNullString converts a sql.NullString to a sql.Null[string] type.
+func FromNullString(n sql.NullString) sql.Null[string] {
+	return sql.Null[string]{V: n.String, Valid: n.Valid}
+}
+
+// ToNullString converts a sql.Null[string] to a sql.NullString type.
+func ToNullString(n sql.Null[string]) sql.NullString {
+	return sql.NullString{String: n.V, Valid: n.Valid}
+}
+
+// FromNullInt64 converts a sql.NullInt64 to a sql.Null[int64] type.
+func FromNullInt64(n sql.NullInt64) sql.Null[int64] {
+	return sql.Null[int64]{V: n.Int64, Valid: n.Valid}
+}
+
+// ToNullInt64 converts a sql.Null[int64] to a sql.NullInt64 type.
+func ToNullInt64(n sql.Null[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: n.V, Valid: n.Valid}
+}
+
+// FromNullInt32 converts a sql.NullInt32 to a sql.Null[int32] type.
+func FromNullInt32(n sql.NullInt32) sql.Null[int32] {
+	return sql.Null[int32]{V: n.Int32, Valid: n.Valid}
+}
+
+// ToNullInt32 converts a sql.Null[int32] to a sql.NullInt32 type.
+func ToNullInt32(n sql.Null[int32]) sql.NullInt32 {
+	return sql.NullInt32{Int32: n.V, Valid: n.Valid}
+}
+
+// FromNullInt16 converts a sql.NullInt16 to a sql.Null[int16] type.
+func FromNullInt16(n sql.NullInt16) sql.Null[int16] {
+	return sql.Null[int16]{V: n.Int16, Valid: n.Valid}
+}
+
+// ToNullInt16 converts a sql.Null[int16] to a sql.NullInt16 type.
+func ToNullInt16(n sql.Null[int16]) sql.NullInt16 {
+	return sql.NullInt16{Int16: n.V, Valid: n.Valid}
+}
+
+// FromNullByte converts a sql.NullByte to a sql.Null[byte] type.
+func FromNullByte(n sql.NullByte) sql.Null[byte] {
+	return sql.Null[byte]{V: n.Byte, Valid: n.Valid}
+}
+
+// ToNullByte converts a sql.Null[byte] to a sql.NullByte type.
+func ToNullByte(n sql.Null[byte]) sql.NullByte {
+	return sql.NullByte{Byte: n.V, Valid: n.Valid}
+}
+
+// FromNullFloat64 converts a sql.NullFloat64 to a sql.Null[float64] type.
+func FromNullFloat64(n sql.NullFloat64) sql.Null[float64] {
+	return sql.Null[float64]{V: n.Float64, Valid: n.Valid}
+}
+
+// ToNullFloat64 converts a sql.Null[float64] to a sql.NullFloat64 type.
+func ToNullFloat64(n sql.Null[float64]) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: n.V, Valid: n.Valid}
+}
+
+// FromNullBool converts a sql.NullBool to a sql.Null[bool] type.
+func FromNullBool(n sql.NullBool) sql.Null[bool] {
+	return sql.Null[bool]{V: n.Bool, Valid: n.Valid}
+}
+
+// ToNullBool converts a sql.Null[bool] to a sql.NullBool type.
+func ToNullBool(n sql.Null[bool]) sql.NullBool {
+	return sql.NullBool{Bool: n.V, Valid: n.Valid}
+}
+
+// FromNullTime converts a sql.NullTime to a sql.Null[time.Time] type.
+func FromNullTime(n sql.NullTime) sql.Null[time.Time] {
+	return sql.Null[time.Time]{V: n.Time, Valid: n.Valid}
+}
+
+// ToNullTime converts a sql.Null[time.Time] to a sql.NullTime type.
+func ToNullTime(n sql.Null[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: n.V, Valid: n.Valid}
+}
+
+// FromNullUUID converts a uuid.NullUUID to a sql.Null[uuid.UUID] type.
+func FromNullUUID(n uuid.NullUUID) sql.Null[uuid.UUID] {
+	return sql.Null[uuid.UUID]{V: n.UUID, Valid: n.Valid}
+}
+
+// ToNullUUID converts a sql.Null[uuid.UUID] to a uuid.NullUUID type.
+func ToNullUUID(n sql.Null[uuid.UUID]) uuid.NullUUID {
+	return uuid.NullUUID{UUID: n.V, Valid: n.Valid}
+}
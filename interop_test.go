@@ -0,0 +1,83 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// compareRoundTrip ensures a legacy null type survives conversion to sql.Null[T] and back.
+func compareRoundTrip[L comparable, T comparable](t *testing.T, legacy L, value T, valid bool, from func(L) sql.Null[T], to func(sql.Null[T]) L) {
+	t.Helper()
+
+	generic := from(legacy)
+
+	if generic.Valid != valid {
+		t.Fatalf("validity mismatch got '%v', expected: '%v'", generic.Valid, valid)
+	}
+
+	if generic.V != value {
+		t.Fatalf("result: '%v' does not equal expected: '%v'", generic.V, value)
+	}
+
+	if result := to(generic); result != legacy {
+		t.Fatalf("round trip result: '%v' does not equal expected: '%v'", result, legacy)
+	}
+}
+
+func TestNullInterop(t *testing.T) {
+	now := time.Now()
+	id := uuid.New()
+
+	for _, valid := range []bool{true, false} {
+		state := "valid"
+		if !valid {
+			state = "null"
+		}
+
+		t.Run("successfully convert "+state+" sql.NullString", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullString{String: "foo", Valid: valid}, "foo", valid, FromNullString, ToNullString)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullInt64", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullInt64{Int64: 42, Valid: valid}, int64(42), valid, FromNullInt64, ToNullInt64)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullInt32", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullInt32{Int32: 42, Valid: valid}, int32(42), valid, FromNullInt32, ToNullInt32)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullInt16", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullInt16{Int16: 42, Valid: valid}, int16(42), valid, FromNullInt16, ToNullInt16)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullByte", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullByte{Byte: 1, Valid: valid}, byte(1), valid, FromNullByte, ToNullByte)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullFloat64", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullFloat64{Float64: 1.5, Valid: valid}, 1.5, valid, FromNullFloat64, ToNullFloat64)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullBool", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullBool{Bool: true, Valid: valid}, true, valid, FromNullBool, ToNullBool)
+		})
+
+		t.Run("successfully convert "+state+" sql.NullTime", func(t *testing.T) {
+			compareRoundTrip(t, sql.NullTime{Time: now, Valid: valid}, now, valid, FromNullTime, ToNullTime)
+		})
+
+		t.Run("successfully convert "+state+" uuid.NullUUID", func(t *testing.T) {
+			compareRoundTrip(t, uuid.NullUUID{UUID: id, Valid: valid}, id, valid, FromNullUUID, ToNullUUID)
+		})
+	}
+}
@@ -0,0 +1,517 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	microsPerSecond = 1000000
+	microsPerMinute = 60 * microsPerSecond
+	microsPerHour   = 60 * microsPerMinute
+	microsPerDay    = 24 * microsPerHour
+)
+
+// Interval is a value stored in an INTERVAL column.
+// Like Postgres it keeps months and days apart from the time of the interval, as neither has a fixed length.
+// https://www.postgresql.org/docs/current/datatype-datetime.html#DATATYPE-INTERVAL-INPUT
+type Interval struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+}
+
+// IntervalOf returns the interval of the duration.
+// The duration is truncated to whole microseconds, the resolution of an INTERVAL column.
+func IntervalOf(d time.Duration) Interval {
+	return Interval{Microseconds: int64(d / time.Microsecond)}
+}
+
+// ParseInterval parses the text output of an INTERVAL column, in the postgres style, e.g. "1 year 2 mons -3 days 04:05:06.5",
+// or in the ISO 8601 style, e.g. "P1Y2M-3DT4H5M6.5S".
+// Invalid input returns a *ConversionError wrapping ErrInvalidFormat, or ErrOverflow.
+func ParseInterval(in string) (Interval, error) {
+	var (
+		p   intervalParts
+		err error
+	)
+
+	s := strings.TrimSpace(in)
+
+	if rest, ok := strings.CutPrefix(s, "P"); ok {
+		err = p.parseISO(rest)
+	} else {
+		err = p.parsePostgres(s)
+	}
+
+	if err != nil {
+		return Interval{}, conversionError(in, "Interval", err, nil)
+	}
+
+	i, ok := p.interval()
+	if !ok {
+		return Interval{}, conversionError(in, "Interval", ErrOverflow, nil)
+	}
+
+	return i, nil
+}
+
+// Duration returns the fixed duration of the interval, counting days as 24 hours.
+// Intervals with months, whose length depends on the date they are added to, return an error wrapping ErrNoFixedDuration.
+// Intervals longer than about 292 years return an error wrapping ErrOverflow.
+func (i Interval) Duration() (time.Duration, error) {
+	micros, err := i.fixedMicroseconds("time.Duration")
+	if err != nil {
+		return 0, err
+	}
+
+	if micros > math.MaxInt64/int64(time.Microsecond) || micros < math.MinInt64/int64(time.Microsecond) {
+		return 0, conversionError(i, "time.Duration", ErrOverflow, nil)
+	}
+
+	return time.Duration(micros) * time.Microsecond, nil
+}
+
+// fixedMicroseconds returns the length of the interval in microseconds, counting days as 24 hours.
+func (i Interval) fixedMicroseconds(target string) (int64, error) {
+	if i.Months != 0 {
+		return 0, conversionError(i, target, ErrNoFixedDuration, nil)
+	}
+
+	micros, ok := addMul(i.Microseconds, int64(i.Days), microsPerDay)
+	if !ok {
+		return 0, conversionError(i, target, ErrOverflow, nil)
+	}
+
+	return micros, nil
+}
+
+// String returns the interval in the postgres output style, e.g. "1 year 2 mons -3 days +04:05:06.5".
+func (i Interval) String() string {
+	var parts []string
+
+	for _, c := range []struct {
+		n    int32
+		unit string
+	}{
+		{i.Months / 12, "year"},
+		{i.Months % 12, "mon"},
+		{i.Days, "day"},
+	} {
+		switch c.n {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+c.unit)
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", c.n, c.unit))
+		}
+	}
+
+	if i.Microseconds != 0 || len(parts) == 0 {
+		sign := ""
+
+		switch {
+		case i.Microseconds < 0:
+			sign = "-"
+		case i.Months < 0 || i.Days < 0:
+			sign = "+"
+		}
+
+		parts = append(parts, sign+formatClock(i.Microseconds))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatClock formats the absolute value of the microseconds as hours, minutes and seconds, e.g. "04:05:06.5".
+func formatClock(micros int64) string {
+	u := uint64(micros)
+	if micros < 0 {
+		u = -u
+	}
+
+	out := fmt.Sprintf("%02d:%02d:%02d", u/microsPerHour, u/microsPerMinute%60, u/microsPerSecond%60)
+
+	if frac := u % microsPerSecond; frac != 0 {
+		out += strings.TrimRight(fmt.Sprintf(".%06d", frac), "0")
+	}
+
+	return out
+}
+
+// Scan implements the sql.Scanner interface.
+// Use NullInterval to scan nullable columns.
+func (i *Interval) Scan(value any) error {
+	var n NullInterval
+
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+
+	if !n.Valid {
+		return &ConversionError{Source: "NULL", Target: "Interval", Err: fmt.Errorf("%w, use NullInterval", ErrNull)}
+	}
+
+	*i = n.Interval
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The interval is written in the postgres output style, which Postgres accepts as input.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// NullInterval represents an Interval that may be null.
+type NullInterval struct {
+	Interval Interval
+	Valid    bool // Valid is true if Interval is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the text output of INTERVAL columns in the postgres and ISO 8601 styles.
+func (n *NullInterval) Scan(value any) error {
+	var err error
+
+	switch v := value.(type) {
+	case nil:
+		*n = NullInterval{}
+
+		return nil
+	case []byte:
+		n.Interval, err = ParseInterval(string(v))
+	case string:
+		n.Interval, err = ParseInterval(v)
+	default:
+		err = conversionError(value, "NullInterval", ErrUnsupported, nil)
+	}
+
+	if err != nil {
+		*n = NullInterval{}
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullInterval) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Interval.Value()
+}
+
+// NullIntervalOf converts the duration or duration pointer to a NullInterval type.
+// A nil pointer is converted to null.
+func NullIntervalOf[T time.Duration | *time.Duration](d T) NullInterval {
+	n := nullOf[time.Duration](d)
+
+	return NullInterval{Interval: IntervalOf(n.V), Valid: n.Valid}
+}
+
+// UnwrapInterval unwraps the NullInterval to a time.Duration pointer.
+// If the value is null the function will return nil.
+// Intervals without a fixed duration return an error as described by Interval.Duration.
+func UnwrapInterval(n NullInterval) (*time.Duration, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	d, err := n.Interval.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// intervalParts accumulates the components of a parsed interval.
+type intervalParts struct {
+	months   int64
+	days     int64
+	micros   int64
+	overflow bool
+}
+
+// add adds n times the unit to the component.
+func (p *intervalParts) add(component *int64, n, unit int64) {
+	v, ok := addMul(*component, n, unit)
+
+	*component, p.overflow = v, p.overflow || !ok
+}
+
+// interval returns the accumulated interval, reporting whether its components are in range.
+func (p *intervalParts) interval() (Interval, bool) {
+	if p.overflow || p.months != int64(int32(p.months)) || p.days != int64(int32(p.days)) {
+		return Interval{}, false
+	}
+
+	return Interval{Months: int32(p.months), Days: int32(p.days), Microseconds: p.micros}, true
+}
+
+// parsePostgres parses an interval in the postgres style, a list of quantities followed by an optional time of day.
+func (p *intervalParts) parsePostgres(s string) error {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ErrInvalidFormat
+	}
+
+	for len(fields) > 0 {
+		if strings.Contains(fields[0], ":") {
+			micros, err := parseClock(fields[0])
+			if err != nil {
+				return err
+			}
+
+			p.add(&p.micros, micros, 1)
+			fields = fields[1:]
+
+			continue
+		}
+
+		if len(fields) < 2 {
+			return ErrInvalidFormat
+		}
+
+		n, err := parseIntervalInt(fields[0])
+		if err != nil {
+			return err
+		}
+
+		switch strings.TrimSuffix(strings.ToLower(fields[1]), "s") {
+		case "year":
+			p.add(&p.months, n, 12)
+		case "mon", "month":
+			p.add(&p.months, n, 1)
+		case "week":
+			p.add(&p.days, n, 7)
+		case "day":
+			p.add(&p.days, n, 1)
+		case "hour":
+			p.add(&p.micros, n, microsPerHour)
+		case "min", "minute":
+			p.add(&p.micros, n, microsPerMinute)
+		case "sec", "second":
+			p.add(&p.micros, n, microsPerSecond)
+		default:
+			return ErrInvalidFormat
+		}
+
+		fields = fields[2:]
+	}
+
+	return nil
+}
+
+// parseISO parses an interval in the ISO 8601 format with designators, without its leading P.
+func (p *intervalParts) parseISO(s string) error {
+	date, clock, hasClock := strings.Cut(s, "T")
+	if s == "" || (hasClock && clock == "") {
+		return ErrInvalidFormat
+	}
+
+	err := parseDesignators(date, func(n string, designator rune) error {
+		v, err := parseIntervalInt(n)
+		if err != nil {
+			return err
+		}
+
+		switch designator {
+		case 'Y':
+			p.add(&p.months, v, 12)
+		case 'M':
+			p.add(&p.months, v, 1)
+		case 'W':
+			p.add(&p.days, v, 7)
+		case 'D':
+			p.add(&p.days, v, 1)
+		default:
+			return ErrInvalidFormat
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return parseDesignators(clock, func(n string, designator rune) error {
+		if designator == 'S' {
+			micros, err := parseSeconds(n)
+			if err != nil {
+				return err
+			}
+
+			p.add(&p.micros, micros, 1)
+
+			return nil
+		}
+
+		v, err := parseIntervalInt(n)
+		if err != nil {
+			return err
+		}
+
+		switch designator {
+		case 'H':
+			p.add(&p.micros, v, microsPerHour)
+		case 'M':
+			p.add(&p.micros, v, microsPerMinute)
+		default:
+			return ErrInvalidFormat
+		}
+
+		return nil
+	})
+}
+
+// parseDesignators calls fn for every number and the designator following it, e.g. "1Y2M".
+func parseDesignators(s string, fn func(n string, designator rune) error) error {
+	for s != "" {
+		i := strings.IndexFunc(s, unicode.IsLetter)
+		if i <= 0 {
+			return ErrInvalidFormat
+		}
+
+		if err := fn(s[:i], rune(s[i])); err != nil {
+			return err
+		}
+
+		s = s[i+1:]
+	}
+
+	return nil
+}
+
+// parseClock parses a time of day quantity, e.g. "-04:05:06.5", to microseconds.
+// The hours are not limited to a day.
+func parseClock(s string) (int64, error) {
+	sign := int64(1)
+
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, ErrInvalidFormat
+	}
+
+	hours, err := parseIntervalInt(parts[0])
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := parseIntervalInt(parts[1])
+	if err != nil {
+		return 0, err
+	}
+
+	var seconds int64
+
+	if len(parts) == 3 {
+		if seconds, err = parseSeconds(parts[2]); err != nil {
+			return 0, err
+		}
+	}
+
+	// The sign applies to the whole quantity, so the components must not carry their own.
+	if hours < 0 || minutes < 0 || minutes > 59 || seconds < 0 || seconds >= microsPerMinute {
+		return 0, ErrInvalidFormat
+	}
+
+	micros, ok := addMul(seconds, minutes, microsPerMinute)
+	if ok {
+		micros, ok = addMul(micros, hours, microsPerHour)
+	}
+
+	if !ok {
+		return 0, ErrOverflow
+	}
+
+	return sign * micros, nil
+}
+
+// parseSeconds parses a possibly fractional number of seconds to microseconds.
+// Digits beyond the microsecond resolution of an INTERVAL column are truncated.
+func parseSeconds(s string) (int64, error) {
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	micros, err := parseIntervalInt(whole)
+	if err != nil {
+		return 0, err
+	}
+
+	micros, ok := addMul(0, micros, microsPerSecond)
+	if !ok {
+		return 0, ErrOverflow
+	}
+
+	if !hasFrac {
+		return micros, nil
+	}
+
+	if frac == "" || strings.TrimLeft(frac, "0123456789") != "" {
+		return 0, ErrInvalidFormat
+	}
+
+	frac = (frac + "00000")[:6]
+
+	f, _ := strconv.ParseInt(frac, 10, 64)
+	if strings.HasPrefix(whole, "-") {
+		f = -f
+	}
+
+	return micros + f, nil
+}
+
+// parseIntervalInt parses a signed decimal integer component of an interval.
+func parseIntervalInt(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, ErrOverflow
+	}
+
+	if err != nil {
+		return 0, ErrInvalidFormat
+	}
+
+	return n, nil
+}
+
+// addMul returns acc + n*unit, reporting whether the result fits in an int64.
+func addMul(acc, n, unit int64) (int64, bool) {
+	v := n * unit
+	if unit != 0 && v/unit != n {
+		return 0, false
+	}
+
+	sum := acc + v
+	if (v > 0 && sum < acc) || (v < 0 && sum > acc) {
+		return 0, false
+	}
+
+	return sum, true
+}
@@ -0,0 +1,191 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected Interval
+		err      error
+	}{
+		{name: "should parse zero interval", input: "00:00:00", expected: Interval{}},
+		{name: "should parse time", input: "04:05:06.789", expected: Interval{Microseconds: 4*microsPerHour + 5*microsPerMinute + 6789000}},
+		{name: "should parse hours above a day", input: "100:00:00", expected: Interval{Microseconds: 100 * microsPerHour}},
+		{name: "should parse months and days", input: "1 year 2 mons 3 days", expected: Interval{Months: 14, Days: 3}},
+		{name: "should parse mixed signs", input: "-1 days +02:03:00", expected: Interval{Days: -1, Microseconds: 2*microsPerHour + 3*microsPerMinute}},
+		{name: "should parse negative time", input: "1 mon -00:00:01.5", expected: Interval{Months: 1, Microseconds: -1500000}},
+		{name: "should parse input units", input: "2 weeks 3 hours 4 mins", expected: Interval{Days: 14, Microseconds: 3*microsPerHour + 4*microsPerMinute}},
+		{name: "should parse ISO 8601", input: "P1Y2M3DT4H5M6.789S", expected: Interval{Months: 14, Days: 3, Microseconds: 4*microsPerHour + 5*microsPerMinute + 6789000}},
+		{name: "should parse negative ISO 8601", input: "P-1Y-2M3DT-4H-5M-6.5S", expected: Interval{Months: -14, Days: 3, Microseconds: -(4*microsPerHour + 5*microsPerMinute + 6500000)}},
+		{name: "should parse zero ISO 8601", input: "PT0S", expected: Interval{}},
+		{name: "should fail to parse empty string", input: "", err: ErrInvalidFormat},
+		{name: "should fail to parse unknown unit", input: "1 fortnight", err: ErrInvalidFormat},
+		{name: "should fail to parse invalid minutes", input: "01:60:00", err: ErrInvalidFormat},
+		{name: "should fail to parse ISO 8601 without components", input: "PT", err: ErrInvalidFormat},
+		{name: "should fail to parse ISO 8601 with unknown designator", input: "P1X", err: ErrInvalidFormat},
+		{name: "should fail to parse too many months", input: "200000000 years", err: ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseInterval(tc.input)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("error should wrap '%v', got: '%v'", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%+v', expected: '%+v'", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	testCases := []struct {
+		input    Interval
+		expected string
+	}{
+		{input: Interval{}, expected: "00:00:00"},
+		{input: Interval{Months: 14, Days: 1}, expected: "1 year 2 mons 1 day"},
+		{input: Interval{Days: -1, Microseconds: 2*microsPerHour + 3*microsPerMinute}, expected: "-1 days +02:03:00"},
+		{input: Interval{Microseconds: -1500000}, expected: "-00:00:01.5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if result := tc.input.String(); result != tc.expected {
+				t.Fatalf("result mismatch got '%s', expected: '%s'", result, tc.expected)
+			}
+
+			// The output must be accepted as input.
+			if result, err := ParseInterval(tc.expected); err != nil || result != tc.input {
+				t.Fatalf("round trip mismatch got '%+v' (%v), expected: '%+v'", result, err, tc.input)
+			}
+		})
+	}
+}
+
+func TestIntervalDuration(t *testing.T) {
+	t.Run("successfully convert days and time", func(t *testing.T) {
+		result, err := Interval{Days: 1, Microseconds: microsPerHour}.Duration()
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result != 25*time.Hour {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, 25*time.Hour)
+		}
+	})
+
+	t.Run("should reject months", func(t *testing.T) {
+		if _, err := (Interval{Months: 1}).Duration(); !errors.Is(err, ErrNoFixedDuration) {
+			t.Fatalf("error should wrap ErrNoFixedDuration, got: '%v'", err)
+		}
+	})
+
+	t.Run("should reject intervals longer than a time.Duration", func(t *testing.T) {
+		if _, err := (Interval{Days: 300 * 366}).Duration(); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+
+	t.Run("should round trip through IntervalOf", func(t *testing.T) {
+		d := -90*time.Minute - 500*time.Microsecond
+
+		if result, err := IntervalOf(d).Duration(); err != nil || result != d {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '%v'", result, err, d)
+		}
+	})
+}
+
+func TestNullIntervalScan(t *testing.T) {
+	t.Run("successfully scan text output", func(t *testing.T) {
+		var n NullInterval
+
+		if err := n.Scan([]byte("1 day 01:00:00")); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if !n.Valid || n.Interval != (Interval{Days: 1, Microseconds: microsPerHour}) {
+			t.Fatalf("result mismatch got '%+v'", n)
+		}
+	})
+
+	t.Run("successfully scan NULL", func(t *testing.T) {
+		n := NullInterval{Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", n, err)
+		}
+	})
+
+	t.Run("should fail to scan NULL into Interval", func(t *testing.T) {
+		var i Interval
+
+		if err := i.Scan(nil); !errors.Is(err, ErrNull) {
+			t.Fatalf("error should wrap ErrNull, got: '%v'", err)
+		}
+	})
+
+	t.Run("should fail to scan unsupported type", func(t *testing.T) {
+		var n NullInterval
+
+		if err := n.Scan(int64(42)); !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("error should wrap ErrUnsupported, got: '%v'", err)
+		}
+	})
+
+	t.Run("successfully write value", func(t *testing.T) {
+		result, err := NullIntervalOf(90 * time.Minute).Value()
+		if err != nil || result != "01:30:00" {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '01:30:00'", result, err)
+		}
+
+		if result, err := (NullInterval{}).Value(); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+}
+
+func TestUnwrapInterval(t *testing.T) {
+	t.Run("successfully unwrap valid interval", func(t *testing.T) {
+		d := 42 * time.Second
+
+		result, err := UnwrapInterval(NullIntervalOf(&d))
+		if err != nil || result == nil || *result != d {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '%v'", result, err, d)
+		}
+	})
+
+	t.Run("successfully unwrap null to nil", func(t *testing.T) {
+		if result, err := UnwrapInterval(NullIntervalOf[*time.Duration](nil)); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject months", func(t *testing.T) {
+		if _, err := UnwrapInterval(NullInterval{Interval: Interval{Months: 1}, Valid: true}); !errors.Is(err, ErrNoFixedDuration) {
+			t.Fatalf("error should wrap ErrNoFixedDuration, got: '%v'", err)
+		}
+	})
+}
@@ -0,0 +1,426 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// tagName is the struct tag used to override the name a field is matched by.
+// A tag value of "-" excludes the field from mapping.
+const tagName = "sqlmap"
+
+// convertFunc copies the src value into the dst value, converting it along the way.
+type convertFunc func(dst, src reflect.Value)
+
+// Types of values that are converted as a whole rather than field by field.
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	valuerType  = reflect.TypeFor[driver.Valuer]()
+)
+
+// convertKey identifies a conversion from one type to another.
+type convertKey struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// converter adapts a typed conversion function to a convertFunc.
+func converter[S, D any](fn func(S) D) (convertKey, convertFunc) {
+	key := convertKey{src: reflect.TypeFor[S](), dst: reflect.TypeFor[D]()}
+
+	return key, func(dst, src reflect.Value) {
+		dst.Set(reflect.ValueOf(fn(src.Interface().(S))))
+	}
+}
+
+// converters holds the conversions applied by Map when the field types differ.
+var converters = map[convertKey]convertFunc{}
+
+// register adds the conversion to the converters used by Map.
+func register(key convertKey, fn convertFunc) {
+	converters[key] = fn
+}
+
+func init() {
+	register(converter(NullString[string]))
+	register(converter(NullString[*string]))
+	register(converter(NullInt64[int64]))
+	register(converter(NullInt64[*int64]))
+	register(converter(NullInt32[int32]))
+	register(converter(NullInt32[*int32]))
+	register(converter(NullInt16[int16]))
+	register(converter(NullInt16[*int16]))
+	register(converter(NullByte[byte]))
+	register(converter(NullByte[*byte]))
+	register(converter(NullFloat64[float64]))
+	register(converter(NullFloat64[*float64]))
+	register(converter(NullReal[float32]))
+	register(converter(NullReal[*float32]))
+	register(converter(NullBoolean[bool]))
+	register(converter(NullBoolean[*bool]))
+	register(converter(NullTime[time.Time]))
+	register(converter(NullTime[*time.Time]))
+	register(converter(NullTimeFromTimestamp))
+	register(converter(NullStringFromWrapper))
+	register(converter(NullInt64FromWrapper))
+	register(converter(NullInt32FromWrapper))
+	register(converter(NullFloat64FromWrapper[*wrapperspb.DoubleValue]))
+	register(converter(NullFloat64FromWrapper[*wrapperspb.FloatValue]))
+	register(converter(NullFloat32FromWrapper))
+	register(converter(NullBooleanFromWrapper))
+	register(converter(NullUint64FromWrapper))
+	register(converter(BytesFromWrapper))
+	register(converter(NullBytesFromWrapper))
+	register(converter(NullBytesOf[[]byte]))
+	register(converter(NullBytesOf[*[]byte]))
+	register(converter(NullUUID[uuid.UUID]))
+	register(converter(NullUUID[*uuid.UUID]))
+	register(converter(NullUint64From[uint64]))
+	register(converter(NullUint64From[*uint64]))
+
+	register(converter(UnwrapString))
+	register(converter(UnwrapStringOrZero))
+	register(converter(UnwrapInt64))
+	register(converter(UnwrapInt64OrZero))
+	register(converter(UnwrapInt32))
+	register(converter(UnwrapInt32OrZero))
+	register(converter(UnwrapInt16))
+	register(converter(UnwrapInt16OrZero))
+	register(converter(UnwrapByte))
+	register(converter(UnwrapByteOrZero))
+	register(converter(UnwrapFloat64))
+	register(converter(UnwrapFloat64OrZero))
+	register(converter(UnwrapBoolean))
+	register(converter(UnwrapBooleanOrZero))
+	register(converter(UnwrapTime))
+	register(converter(UnwrapTimePtr))
+	register(converter(UnwrapTimestamp))
+	register(converter(UnwrapStringValue))
+	register(converter(UnwrapInt64Value[sql.NullInt64]))
+	register(converter(UnwrapInt32Value[sql.NullInt32]))
+	register(converter(UnwrapInt32Value[sql.NullInt16]))
+	register(converter(UnwrapDoubleValue))
+	register(converter(UnwrapFloat32Value))
+	register(converter(UnwrapBoolValue))
+	register(converter(UnwrapUInt64Value))
+	register(converter(UnwrapBytesValue))
+	register(converter(UnwrapNullBytesValue))
+	register(converter(UnwrapBytes))
+	register(converter(UnwrapBytesPtr))
+	register(converter(UnwrapUUID))
+	register(converter(UnwrapUUIDPtr))
+	register(converter(UnwrapUint64))
+	register(converter(UnwrapUint64OrZero))
+}
+
+// planKey identifies a cached mapping plan.
+type planKey struct {
+	src    reflect.Type
+	dst    reflect.Type
+	strict bool
+}
+
+// fieldPlan describes how a single destination field is filled.
+type fieldPlan struct {
+	src     []int
+	dst     []int
+	convert convertFunc
+}
+
+// plans caches the mapping plans by source and destination type.
+var plans sync.Map
+
+// Map copies the fields of the src struct into the dst struct, which must be a pointer.
+// Fields are matched by name, or by the name given in a `sqlmap:"name"` struct tag, and converted using the functions of this package.
+// For example a *string field is converted to a sql.NullString and a *timestamppb.Timestamp to a sql.NullTime.
+// Destination fields without a matching source field are left untouched.
+// Nested structs are mapped field by field, but every one of their fields must be matched,
+// and values such as time.Time or types implementing sql.Scanner are never mapped field by field.
+//
+// The mapping plan for each pair of types is computed once and cached, so repeated calls are cheap.
+func Map(dst, src any) error {
+	return mapStruct(dst, src, false)
+}
+
+// MapModel copies the fields of the src model, such as a struct generated by sqlc, into the dst struct, which must be a pointer.
+// Fields are matched and converted like Map does, using the Unwrap functions to turn sql null types into pointers or plain values.
+// Unlike Map every destination field must have a matching source field,
+// so schema drift is reported as an error naming the field path instead of silently producing zero values.
+//
+// Matching is one-way: source fields without a matching destination field are ignored, so a domain struct may
+// leave out columns such as password hashes. A column added to the model is therefore only reported once a
+// destination field asks for it; removed or renamed columns always are.
+func MapModel(dst, src any) error {
+	return mapStruct(dst, src, true)
+}
+
+// mapStruct copies the fields of src into dst according to the cached plan for their types.
+// If strict is set every destination field must have a matching source field.
+func mapStruct(dst, src any, strict bool) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sqlmap: destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("sqlmap: source must be a struct or a non-nil pointer to a struct, got %T", src)
+	}
+
+	p, err := plan(dv.Elem().Type(), sv.Type(), strict)
+	if err != nil {
+		return err
+	}
+
+	p(dv.Elem(), sv)
+
+	return nil
+}
+
+// plan returns the cached mapping plan for the given types, building it if needed.
+func plan(dst, src reflect.Type, strict bool) (convertFunc, error) {
+	key := planKey{src: src, dst: dst, strict: strict}
+
+	if p, ok := plans.Load(key); ok {
+		return p.(convertFunc), nil
+	}
+
+	p, err := buildPlan(dst, src, strict, "")
+	if err != nil {
+		return nil, err
+	}
+
+	plans.Store(key, p)
+
+	return p, nil
+}
+
+// buildPlan matches the fields of the dst and src struct types and selects a conversion for each of them.
+// Every field that cannot be mapped is reported in the returned error.
+// The path is the field path of the structs within the top level destination, used in error messages.
+func buildPlan(dst, src reflect.Type, strict bool, path string) (convertFunc, error) {
+	srcFields := mappableFields(src)
+
+	var (
+		fields []fieldPlan
+		errs   []error
+	)
+
+	for _, df := range mappableFields(dst) {
+		name := fieldName(df)
+		fieldPath := path + df.Name
+
+		sf, ok := matchField(srcFields, name)
+		if !ok {
+			if strict {
+				errs = append(errs, &ConversionError{Field: fieldPath, Target: df.Type.String(), Err: fmt.Errorf("%w in %s", ErrNoMatchingField, src)})
+			}
+
+			continue
+		}
+
+		convert, err := conversion(df.Type, sf.Type, strict, fieldPath)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		fields = append(fields, fieldPlan{src: sf.Index, dst: df.Index, convert: convert})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return func(dst, src reflect.Value) {
+		for _, f := range fields {
+			f.convert(dst.FieldByIndex(f.dst), src.FieldByIndex(f.src))
+		}
+	}, nil
+}
+
+// conversion selects the function used to convert a value of the src type to the dst type.
+func conversion(dst, src reflect.Type, strict bool, path string) (convertFunc, error) {
+	if src.AssignableTo(dst) {
+		return func(dst, src reflect.Value) { dst.Set(src) }, nil
+	}
+
+	if convert, ok := converters[convertKey{src: src, dst: dst}]; ok {
+		return convert, nil
+	}
+
+	if convert, ok := genericConversion(dst, src); ok {
+		return convert, nil
+	}
+
+	// Nested structs are mapped field by field, and every field must be matched,
+	// so a mismatched type can never be mapped partially.
+	if plainStruct(dst) && plainStruct(src) {
+		return buildPlan(dst, src, true, path+".")
+	}
+
+	return nil, &ConversionError{Field: path, Source: src.String(), Target: dst.String(), Err: ErrUnsupported}
+}
+
+// plainStruct reports whether the type is a struct that can be mapped field by field.
+// Values such as time.Time and the sql null types are not, their fields are not independent of each other.
+func plainStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+
+	return !reflect.PointerTo(t).Implements(scannerType) && !t.Implements(valuerType)
+}
+
+// genericConversion handles the conversion between T or *T values and an instantiation of sql.Null[T].
+func genericConversion(dst, src reflect.Type) (convertFunc, bool) {
+	switch {
+	case isGenericNull(dst):
+		return toGenericNull(dst.Field(0).Type, src)
+	case isGenericNull(src):
+		return fromGenericNull(dst, src.Field(0).Type)
+	}
+
+	return nil, false
+}
+
+// toGenericNull converts a T or *T value to a sql.Null[T].
+func toGenericNull(elem, src reflect.Type) (convertFunc, bool) {
+	switch {
+	case src.AssignableTo(elem):
+		return func(dst, src reflect.Value) {
+			dst.Field(0).Set(src)
+			dst.Field(1).SetBool(true)
+		}, true
+	case src.Kind() == reflect.Pointer && src.Elem().AssignableTo(elem):
+		return func(dst, src reflect.Value) {
+			if src.IsNil() {
+				dst.SetZero()
+
+				return
+			}
+
+			dst.Field(0).Set(src.Elem())
+			dst.Field(1).SetBool(true)
+		}, true
+	}
+
+	return nil, false
+}
+
+// fromGenericNull converts a sql.Null[T] to a T or *T value.
+// A null value results in a nil pointer or the zero value of T.
+func fromGenericNull(dst, elem reflect.Type) (convertFunc, bool) {
+	switch {
+	case elem.AssignableTo(dst):
+		return func(dst, src reflect.Value) {
+			if !src.Field(1).Bool() {
+				dst.SetZero()
+
+				return
+			}
+
+			dst.Set(src.Field(0))
+		}, true
+	case dst.Kind() == reflect.Pointer && elem.AssignableTo(dst.Elem()):
+		return func(dst, src reflect.Value) {
+			if !src.Field(1).Bool() {
+				dst.SetZero()
+
+				return
+			}
+
+			v := reflect.New(dst.Type().Elem())
+			v.Elem().Set(src.Field(0))
+			dst.Set(v)
+		}, true
+	}
+
+	return nil, false
+}
+
+// isGenericNull reports whether the type is an instantiation of sql.Null[T].
+func isGenericNull(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		t.PkgPath() == reflect.TypeFor[sql.Null[any]]().PkgPath() &&
+		strings.HasPrefix(t.Name(), "Null[")
+}
+
+// mappableFields returns the exported fields of the struct type, including those promoted from embedded structs.
+// Fields tagged with `sqlmap:"-"` and fields promoted through embedded pointers are skipped.
+func mappableFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() || f.Tag.Get(tagName) == "-" || viaPointer(t, f.Index) {
+			continue
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+// viaPointer reports whether the field at the index is promoted through an embedded pointer.
+func viaPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		f := t.Field(i)
+		if f.Type.Kind() == reflect.Pointer {
+			return true
+		}
+
+		t = f.Type
+	}
+
+	return false
+}
+
+// fieldName returns the name the field is matched by.
+func fieldName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get(tagName), ","); name != "" {
+		return name
+	}
+
+	return f.Name
+}
+
+// matchField finds the field matched by the name.
+// An exact match is preferred over a match under Unicode case folding, e.g. ID and Id.
+func matchField(fields []reflect.StructField, name string) (reflect.StructField, bool) {
+	for _, f := range fields {
+		if fieldName(f) == name {
+			return f, true
+		}
+	}
+
+	for _, f := range fields {
+		if strings.EqualFold(fieldName(f), name) {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
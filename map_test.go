@@ -0,0 +1,295 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type createFooRequest struct {
+	Bar       *string
+	Biz       string
+	Count     int64
+	Ratio     *float32
+	CreatedAt *timestamppb.Timestamp
+	OwnerID   *uuid.UUID `sqlmap:"owner"`
+	Ignored   string     `sqlmap:"-"`
+	Address   address
+}
+
+type address struct {
+	Street *string
+}
+
+type tracking struct {
+	TraceID *string
+}
+
+type createFooParams struct {
+	Bar       sql.NullString
+	Biz       string
+	Count     sql.NullInt64
+	Ratio     sql.Null[float32]
+	CreatedAt sql.NullTime
+	Owner     uuid.NullUUID
+	Ignored   string
+	Address   addressParams
+	Extra     string
+}
+
+type addressParams struct {
+	Street sql.NullString
+}
+
+func TestMap(t *testing.T) {
+	t.Run("successfully map populated struct", func(t *testing.T) {
+		bar := "bar"
+		ratio := float32(0.5)
+		created := time.Now()
+		owner := uuid.New()
+		street := "Main St"
+
+		src := createFooRequest{
+			Bar:       &bar,
+			Biz:       "biz",
+			Count:     42,
+			Ratio:     &ratio,
+			CreatedAt: timestamppb.New(created),
+			OwnerID:   &owner,
+			Ignored:   "ignored",
+			Address:   address{Street: &street},
+		}
+
+		var dst createFooParams
+
+		if err := Map(&dst, src); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareString(t, &bar, dst.Bar)
+		compareInt64(t, &src.Count, dst.Count)
+		compareNull(t, &ratio, dst.Ratio)
+		compareTime(t, &created, dst.CreatedAt)
+		compareUUID(t, &owner, dst.Owner)
+		compareString(t, &street, dst.Address.Street)
+
+		if dst.Biz != src.Biz {
+			t.Fatalf("result: '%s' does not equal expected: '%s'", dst.Biz, src.Biz)
+		}
+
+		if dst.Ignored != "" {
+			t.Fatalf("ignored field should not be mapped, got: '%s'", dst.Ignored)
+		}
+	})
+
+	t.Run("successfully map null pointers", func(t *testing.T) {
+		dst := createFooParams{Ratio: sql.Null[float32]{V: 1, Valid: true}}
+
+		if err := Map(&dst, &createFooRequest{}); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareString(t, nil, dst.Bar)
+		compareNull(t, nil, dst.Ratio)
+		compareTime(t, nil, dst.CreatedAt)
+		compareUUID(t, nil, dst.Owner)
+		compareString(t, nil, dst.Address.Street)
+	})
+
+	t.Run("should fail to map incompatible fields", func(t *testing.T) {
+		src := struct{ Bar int }{Bar: 1}
+		dst := struct{ Bar sql.NullString }{}
+
+		if err := Map(&dst, src); err == nil {
+			t.Fatalf("function should return error")
+		}
+	})
+
+	t.Run("successfully map fields promoted from unexported embedded struct", func(t *testing.T) {
+		id := "trace"
+		src := struct {
+			tracking
+			Name string
+		}{tracking: tracking{TraceID: &id}, Name: "name"}
+
+		var dst struct {
+			TraceID sql.NullString
+			Name    string
+		}
+
+		if err := Map(&dst, src); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareString(t, &id, dst.TraceID)
+	})
+
+	t.Run("should fail to map mismatched sql null types", func(t *testing.T) {
+		src := struct{ Count sql.NullString }{Count: sql.NullString{String: "42", Valid: true}}
+		dst := struct{ Count sql.NullInt64 }{}
+
+		err := Map(&dst, src)
+		if !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("error should wrap ErrUnsupported, got: '%v'", err)
+		}
+
+		if dst.Count.Valid {
+			t.Fatalf("destination should be untouched, got: '%v'", dst.Count)
+		}
+	})
+
+	t.Run("should fail to map time into timestamp value", func(t *testing.T) {
+		src := struct{ CreatedAt time.Time }{CreatedAt: time.Now()}
+		dst := struct{ CreatedAt timestamppb.Timestamp }{}
+
+		if err := Map(&dst, src); !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("error should wrap ErrUnsupported, got: '%v'", err)
+		}
+	})
+
+	t.Run("should fail to map partially matching nested structs", func(t *testing.T) {
+		src := struct{ Address address }{}
+		dst := struct {
+			Address struct{ Street, Zip sql.NullString }
+		}{}
+
+		if err := Map(&dst, src); !errors.Is(err, ErrNoMatchingField) {
+			t.Fatalf("error should wrap ErrNoMatchingField, got: '%v'", err)
+		}
+	})
+
+	t.Run("should fail to map into non-pointer", func(t *testing.T) {
+		if err := Map(createFooParams{}, createFooRequest{}); err == nil {
+			t.Fatalf("function should return error")
+		}
+	})
+
+	t.Run("should fail to map from non-struct", func(t *testing.T) {
+		if err := Map(&createFooParams{}, "foo"); err == nil {
+			t.Fatalf("function should return error")
+		}
+	})
+}
+
+type foo struct {
+	Bar       sql.NullString
+	Biz       string
+	Count     sql.NullInt64
+	Ratio     sql.Null[float32]
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+	Owner     uuid.NullUUID
+}
+
+type fooResponse struct {
+	Bar       *string
+	Biz       string
+	Count     int64
+	Ratio     *float32
+	CreatedAt time.Time
+	UpdatedAt *timestamppb.Timestamp
+	OwnerID   uuid.UUID `sqlmap:"owner"`
+}
+
+func TestMapModel(t *testing.T) {
+	t.Run("successfully map populated model", func(t *testing.T) {
+		src := foo{
+			Bar:       sql.NullString{String: "bar", Valid: true},
+			Biz:       "biz",
+			Count:     sql.NullInt64{Int64: 42, Valid: true},
+			Ratio:     sql.Null[float32]{V: 0.5, Valid: true},
+			CreatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			Owner:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}
+
+		var dst fooResponse
+
+		if err := MapModel(&dst, &src); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareString(t, dst.Bar, src.Bar)
+		compareInt64(t, &dst.Count, src.Count)
+		compareNull(t, dst.Ratio, src.Ratio)
+		compareTime(t, &dst.CreatedAt, src.CreatedAt)
+		compareUUID(t, &dst.OwnerID, src.Owner)
+
+		if !dst.UpdatedAt.AsTime().Equal(src.UpdatedAt.Time) {
+			t.Fatalf("result: '%v' does not equal expected: '%v'", dst.UpdatedAt.AsTime(), src.UpdatedAt.Time)
+		}
+	})
+
+	t.Run("successfully map null model", func(t *testing.T) {
+		var dst fooResponse
+
+		if err := MapModel(&dst, foo{}); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if dst.Bar != nil || dst.Count != 0 || dst.Ratio != nil || !dst.CreatedAt.IsZero() || dst.UpdatedAt != nil || dst.OwnerID != uuid.Nil {
+			t.Fatalf("result should hold zero values, got: '%+v'", dst)
+		}
+	})
+
+	t.Run("should report unmatched and incompatible fields", func(t *testing.T) {
+		dst := struct {
+			Bar     int
+			Missing string
+		}{}
+
+		err := MapModel(&dst, foo{})
+		if err == nil {
+			t.Fatalf("function should return error")
+		}
+
+		for _, field := range []string{"Bar", "Missing"} {
+			if !strings.Contains(err.Error(), "field "+field+": ") {
+				t.Errorf("error should name field %s, got: '%v'", field, err)
+			}
+		}
+
+		if !errors.Is(err, ErrUnsupported) || !errors.Is(err, ErrNoMatchingField) {
+			t.Errorf("error should wrap ErrUnsupported and ErrNoMatchingField, got: '%v'", err)
+		}
+
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) || convErr.Field != "Bar" || convErr.Source != "sql.NullString" || convErr.Target != "int" {
+			t.Errorf("error should be a ConversionError for field Bar, got: '%+v'", convErr)
+		}
+	})
+
+	t.Run("should ignore source fields without a matching destination field", func(t *testing.T) {
+		dst := struct{ Biz string }{}
+
+		if err := MapModel(&dst, foo{Bar: sql.NullString{String: "bar", Valid: true}, Biz: "biz"}); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if dst.Biz != "biz" {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", dst.Biz, "biz")
+		}
+	})
+
+	t.Run("should report nested field path", func(t *testing.T) {
+		src := struct{ Address addressParams }{}
+		dst := struct{ Address struct{ Zip string } }{}
+
+		err := MapModel(&dst, src)
+		if err == nil || !strings.Contains(err.Error(), "Address.Zip") {
+			t.Fatalf("error should name field Address.Zip, got: '%v'", err)
+		}
+	})
+}
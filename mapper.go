@@ -0,0 +1,140 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Zero selects the types whose zero value a Mapper converts to null.
+type Zero uint
+
+const (
+	ZeroInt64 Zero = 1 << iota
+	ZeroInt32
+	ZeroInt16
+	ZeroByte
+	ZeroFloat64
+	ZeroBoolean
+	ZeroTime
+	ZeroFloat32
+
+	// ZeroAll converts the zero value of every supported type to null.
+	ZeroAll = ZeroInt64 | ZeroInt32 | ZeroInt16 | ZeroByte | ZeroFloat64 | ZeroBoolean | ZeroTime | ZeroFloat32
+)
+
+// Mapper converts native go types to sql null types, and back, according to a configurable null policy.
+// The zero value Mapper behaves exactly like the package level functions.
+//
+// Methods cannot have type parameters, so unlike the package level functions every method accepts a pointer.
+// Use &v to convert a plain value.
+type Mapper struct {
+	// TrimSpace removes leading and trailing white space from strings before they are converted.
+	TrimSpace bool
+	// EmptyStringAsNull converts empty strings to null. It is applied after TrimSpace.
+	EmptyStringAsNull bool
+	// ZeroAsNull converts the zero value of the selected types to null.
+	ZeroAsNull Zero
+	// NilUUIDAsNull converts uuid.Nil to null.
+	NilUUIDAsNull bool
+}
+
+// zeroAsNull converts a valid zero value to null if enabled.
+func zeroAsNull[T comparable](n sql.Null[T], enabled bool) sql.Null[T] {
+	var zero T
+
+	if enabled && n.V == zero {
+		return sql.Null[T]{}
+	}
+
+	return n
+}
+
+// NullString converts the string pointer to a sql.NullString type.
+func (m Mapper) NullString(s *string) sql.NullString {
+	n := NullPtr(s)
+
+	if m.TrimSpace {
+		n.V = strings.TrimSpace(n.V)
+	}
+
+	return ToNullString(zeroAsNull(n, m.EmptyStringAsNull))
+}
+
+// NullInt64 converts the int64 pointer to a sql.NullInt64 type.
+func (m Mapper) NullInt64(i *int64) sql.NullInt64 {
+	return ToNullInt64(zeroAsNull(NullPtr(i), m.ZeroAsNull&ZeroInt64 != 0))
+}
+
+// NullInt32 converts the int32 pointer to a sql.NullInt32 type.
+func (m Mapper) NullInt32(i *int32) sql.NullInt32 {
+	return ToNullInt32(zeroAsNull(NullPtr(i), m.ZeroAsNull&ZeroInt32 != 0))
+}
+
+// NullInt16 converts the int16 pointer to a sql.NullInt16 type.
+func (m Mapper) NullInt16(i *int16) sql.NullInt16 {
+	return ToNullInt16(zeroAsNull(NullPtr(i), m.ZeroAsNull&ZeroInt16 != 0))
+}
+
+// NullByte converts the byte pointer to a sql.NullByte type.
+func (m Mapper) NullByte(b *byte) sql.NullByte {
+	return ToNullByte(zeroAsNull(NullPtr(b), m.ZeroAsNull&ZeroByte != 0))
+}
+
+// NullFloat64 converts the float64 pointer to a sql.NullFloat64 type.
+func (m Mapper) NullFloat64(f *float64) sql.NullFloat64 {
+	return ToNullFloat64(zeroAsNull(NullPtr(f), m.ZeroAsNull&ZeroFloat64 != 0))
+}
+
+// NullFloat32 converts the float32 pointer to a sql.Null[float32] type.
+func (m Mapper) NullFloat32(f *float32) sql.Null[float32] {
+	return zeroAsNull(NullPtr(f), m.ZeroAsNull&ZeroFloat32 != 0)
+}
+
+// NullReal converts the float32 pointer to the sql.NullFloat64 type of a REAL column.
+// The zero value is converted to null if ZeroFloat32 is selected.
+func (m Mapper) NullReal(f *float32) sql.NullFloat64 {
+	return NullReal(Unwrap(m.NullFloat32(f)))
+}
+
+// NullBoolean converts the boolean pointer to a sql.NullBool type.
+func (m Mapper) NullBoolean(b *bool) sql.NullBool {
+	return ToNullBool(zeroAsNull(NullPtr(b), m.ZeroAsNull&ZeroBoolean != 0))
+}
+
+// NullTime converts the time pointer to a sql.NullTime type.
+// The zero time is only treated as null if ZeroTime is selected.
+func (m Mapper) NullTime(t *time.Time) sql.NullTime {
+	n := NullPtr(t)
+
+	if m.ZeroAsNull&ZeroTime != 0 && n.V.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return ToNullTime(n)
+}
+
+// NullUUID converts the UUID pointer to a uuid.NullUUID type.
+func (m Mapper) NullUUID(id *uuid.UUID) uuid.NullUUID {
+	return ToNullUUID(zeroAsNull(NullPtr(id), m.NilUUIDAsNull))
+}
+
+// UnwrapTimestamp unwraps the sql null time to a timestamppb.Timestamp pointer.
+// The zero time is only unwrapped to nil, like null, if ZeroTime is selected.
+func (m Mapper) UnwrapTimestamp(t sql.NullTime) *timestamppb.Timestamp {
+	if m.ZeroAsNull&ZeroTime != 0 && t.Time.IsZero() {
+		return nil
+	}
+
+	return UnwrapTimestamp(t)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMapperNullString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mapper   Mapper
+		input    *string
+		expected *string
+	}{
+		{
+			name:     "default mapper should keep empty strings",
+			mapper:   Mapper{},
+			input:    ptr(""),
+			expected: ptr(""),
+		},
+		{
+			name:     "default mapper should not trim strings",
+			mapper:   Mapper{},
+			input:    ptr(" foo "),
+			expected: ptr(" foo "),
+		},
+		{
+			name:     "should convert empty string to null",
+			mapper:   Mapper{EmptyStringAsNull: true},
+			input:    ptr(""),
+			expected: nil,
+		},
+		{
+			name:     "should trim strings",
+			mapper:   Mapper{TrimSpace: true},
+			input:    ptr(" foo "),
+			expected: ptr("foo"),
+		},
+		{
+			name:     "should convert blank string to null after trimming",
+			mapper:   Mapper{TrimSpace: true, EmptyStringAsNull: true},
+			input:    ptr("  \t "),
+			expected: nil,
+		},
+		{
+			name:     "should keep null strings null",
+			mapper:   Mapper{TrimSpace: true, EmptyStringAsNull: true},
+			input:    nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			compareString(t, tc.expected, tc.mapper.NullString(tc.input))
+		})
+	}
+}
+
+func TestMapperZeroAsNull(t *testing.T) {
+	m := Mapper{ZeroAsNull: ZeroAll}
+
+	t.Run("should convert zero values to null", func(t *testing.T) {
+		compareInt64(t, nil, m.NullInt64(ptr[int64](0)))
+		compareInt32(t, nil, m.NullInt32(ptr[int32](0)))
+		compareInt16(t, nil, m.NullInt16(ptr[int16](0)))
+		compareByte(t, nil, m.NullByte(ptr[byte](0)))
+		compareFloat64(t, nil, m.NullFloat64(ptr[float64](0)))
+		compareNull(t, nil, m.NullFloat32(ptr[float32](0)))
+		compareFloat64(t, nil, m.NullReal(ptr[float32](0)))
+		compareBoolean(t, nil, m.NullBoolean(ptr(false)))
+		compareTime(t, nil, m.NullTime(&time.Time{}))
+	})
+
+	t.Run("should keep non-zero values", func(t *testing.T) {
+		compareInt64(t, ptr[int64](1), m.NullInt64(ptr[int64](1)))
+		compareInt32(t, ptr[int32](1), m.NullInt32(ptr[int32](1)))
+		compareInt16(t, ptr[int16](1), m.NullInt16(ptr[int16](1)))
+		compareByte(t, ptr[byte](1), m.NullByte(ptr[byte](1)))
+		compareFloat64(t, ptr[float64](1), m.NullFloat64(ptr[float64](1)))
+		compareNull(t, ptr[float32](1), m.NullFloat32(ptr[float32](1)))
+		compareFloat64(t, ptr[float64](1), m.NullReal(ptr[float32](1)))
+		compareBoolean(t, ptr(true), m.NullBoolean(ptr(true)))
+
+		now := time.Now()
+		compareTime(t, &now, m.NullTime(&now))
+	})
+
+	t.Run("should only convert selected types", func(t *testing.T) {
+		m := Mapper{ZeroAsNull: ZeroInt64}
+
+		compareInt64(t, nil, m.NullInt64(ptr[int64](0)))
+		compareInt32(t, ptr[int32](0), m.NullInt32(ptr[int32](0)))
+		compareTime(t, &time.Time{}, m.NullTime(&time.Time{}))
+	})
+}
+
+func TestMapperNullUUID(t *testing.T) {
+	t.Run("default mapper should keep uuid.Nil", func(t *testing.T) {
+		compareUUID(t, &uuid.Nil, Mapper{}.NullUUID(&uuid.Nil))
+	})
+
+	t.Run("should convert uuid.Nil to null", func(t *testing.T) {
+		compareUUID(t, nil, Mapper{NilUUIDAsNull: true}.NullUUID(&uuid.Nil))
+	})
+
+	t.Run("should keep non-nil UUIDs", func(t *testing.T) {
+		id := uuid.New()
+
+		compareUUID(t, &id, Mapper{NilUUIDAsNull: true}.NullUUID(&id))
+	})
+}
+
+func TestMapperUnwrapTimestamp(t *testing.T) {
+	t.Run("default mapper should unwrap valid zero time to timestamp", func(t *testing.T) {
+		if result := (Mapper{}).UnwrapTimestamp(sql.NullTime{Valid: true}); result == nil {
+			t.Fatalf("result should not be nil")
+		}
+	})
+
+	t.Run("should unwrap valid zero time to nil", func(t *testing.T) {
+		if result := (Mapper{ZeroAsNull: ZeroTime}).UnwrapTimestamp(sql.NullTime{Valid: true}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+	})
+
+	t.Run("should keep non-zero time", func(t *testing.T) {
+		now := time.Now()
+
+		result := Mapper{ZeroAsNull: ZeroTime}.UnwrapTimestamp(sql.NullTime{Time: now, Valid: true})
+		if result == nil || !result.AsTime().Equal(now) {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, now)
+		}
+	})
+}
+
+// ptr returns a pointer to the value.
+func ptr[T any](v T) *T {
+	return &v
+}
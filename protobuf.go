@@ -9,12 +9,21 @@ package sqlmap
 
 import (
 	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
 
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // NullTimeFromTimestamp converts an timestamppb.Timestamp pointer to a sql.NullTime type.
 // This will save you a conversion when dealing with gRPC requests.
+// Invalid timestamps are converted to null, use NullTimeFromTimestampStrict to reject them instead.
 func NullTimeFromTimestamp(t *timestamppb.Timestamp) sql.NullTime {
 	nTime := sql.NullTime{}
 
@@ -26,13 +35,480 @@ func NullTimeFromTimestamp(t *timestamppb.Timestamp) sql.NullTime {
 	return nTime
 }
 
-// UnwrapTime unwraps the sql null time to a timestamppb.Timestamp pointer.
+// NullTimeFromTimestampStrict converts an timestamppb.Timestamp pointer to a sql.NullTime type.
+// Unlike NullTimeFromTimestamp invalid timestamps, such as seconds before 0001-01-01, are not converted to null.
+// They return a *ConversionError wrapping ErrInvalidTimestamp and the error of the timestamp's CheckValid method,
+// so gRPC handlers can answer with InvalidArgument instead of writing NULL.
+func NullTimeFromTimestampStrict(t *timestamppb.Timestamp) (sql.NullTime, error) {
+	if t == nil {
+		return sql.NullTime{}, nil
+	}
+
+	if err := t.CheckValid(); err != nil {
+		return sql.NullTime{}, conversionError(t, "sql.NullTime", ErrInvalidTimestamp, err)
+	}
+
+	return sql.NullTime{Time: t.AsTime(), Valid: true}, nil
+}
+
+// UnwrapTimestamp unwraps the sql null time to a timestamppb.Timestamp pointer.
+// Only null returns nil, a valid zero time is returned as the 0001-01-01 timestamp,
+// so round trips through NullTimeFromTimestamp are lossless.
+// Use a Mapper with ZeroTime selected to unwrap the zero time to nil as well.
 func UnwrapTimestamp(t sql.NullTime) *timestamppb.Timestamp {
-	tm := UnwrapTime(t)
+	if !t.Valid {
+		return nil
+	}
+
+	return timestamppb.New(t.Time)
+}
+
+// UnwrapTimestampOr unwraps the sql null time to a timestamppb.Timestamp pointer.
+// If the value is null the function will return the fallback value.
+func UnwrapTimestampOr(t sql.NullTime, fallback *timestamppb.Timestamp) *timestamppb.Timestamp {
+	if ts := UnwrapTimestamp(t); ts != nil {
+		return ts
+	}
+
+	return fallback
+}
+
+// UnwrapTimestampOrZero unwraps the sql null time to a timestamppb.Timestamp pointer.
+// If the value is null the function will return the zero timestamp (the Unix epoch) rather than nil.
+func UnwrapTimestampOrZero(t sql.NullTime) *timestamppb.Timestamp {
+	return UnwrapTimestampOr(t, &timestamppb.Timestamp{})
+}
+
+// NullIntervalFromDuration converts a durationpb.Duration pointer to a NullInterval type.
+// A nil duration is converted to null, an invalid duration returns an error wrapping ErrInvalidFormat.
+func NullIntervalFromDuration(d *durationpb.Duration) (NullInterval, error) {
+	if d == nil {
+		return NullInterval{}, nil
+	}
+
+	if err := d.CheckValid(); err != nil {
+		return NullInterval{}, conversionError(d, "NullInterval", ErrInvalidFormat, err)
+	}
+
+	// A durationpb.Duration spans 10000 years, far more than a time.Duration, so the interval is computed from its fields.
+	micros := d.GetSeconds()*microsPerSecond + int64(d.GetNanos())/1000
+
+	return NullInterval{Interval: Interval{Microseconds: micros}, Valid: true}, nil
+}
+
+// UnwrapDuration unwraps the NullInterval to a durationpb.Duration pointer, counting days as 24 hours.
+// If the value is null the function will return nil.
+// Intervals holding months return an error wrapping ErrNoFixedDuration.
+func UnwrapDuration(n NullInterval) (*durationpb.Duration, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	micros, err := n.Interval.fixedMicroseconds("*durationpb.Duration")
+	if err != nil {
+		return nil, err
+	}
+
+	d := &durationpb.Duration{Seconds: micros / microsPerSecond, Nanos: int32(micros%microsPerSecond) * 1000}
+	if err := d.CheckValid(); err != nil {
+		return nil, conversionError(n.Interval, "*durationpb.Duration", ErrOverflow, err)
+	}
+
+	return d, nil
+}
+
+// NullDateFromProto converts a google.type.Date pointer to a NullDate type.
+// A nil date is converted to null.
+// Partial dates, with a zero year, month or day, cannot be stored in a DATE column and return an error wrapping ErrInvalidFormat.
+func NullDateFromProto(d *date.Date) (NullDate, error) {
+	if d == nil {
+		return NullDate{}, nil
+	}
+
+	v := Date{Year: int(d.GetYear()), Month: time.Month(d.GetMonth()), Day: int(d.GetDay())}
+	if v.Year < 1 || !v.IsValid() {
+		return NullDate{}, conversionError(d, "NullDate", ErrInvalidFormat, nil)
+	}
+
+	return NullDate{Date: v, Valid: true}, nil
+}
+
+// UnwrapDateProto unwraps the NullDate to a google.type.Date pointer.
+// If the value is null the function will return nil.
+// Years outside of 1 to 9999, the range of a google.type.Date, return an error wrapping ErrOverflow.
+func UnwrapDateProto(n NullDate) (*date.Date, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if n.Date.Year < 1 || n.Date.Year > 9999 {
+		return nil, conversionError(n.Date, "*date.Date", ErrOverflow, nil)
+	}
+
+	return &date.Date{Year: int32(n.Date.Year), Month: int32(n.Date.Month), Day: int32(n.Date.Day)}, nil
+}
+
+// NullTimeOfDayFromProto converts a google.type.TimeOfDay pointer to a NullTimeOfDay type without offset.
+// A nil time of day is converted to null, nanoseconds are truncated to whole microseconds.
+// Times outside of 00:00:00 and 24:00:00, including leap seconds, return an error wrapping ErrInvalidFormat.
+func NullTimeOfDayFromProto(t *timeofday.TimeOfDay) (NullTimeOfDay, error) {
+	if t == nil {
+		return NullTimeOfDay{}, nil
+	}
+
+	v := TimeOfDay{Hour: int(t.GetHours()), Minute: int(t.GetMinutes()), Second: int(t.GetSeconds()), Microsecond: int(t.GetNanos() / 1000)}
+	if !v.IsValid() || t.GetNanos() < 0 || t.GetNanos() >= 1e9 {
+		return NullTimeOfDay{}, conversionError(t, "NullTimeOfDay", ErrInvalidFormat, nil)
+	}
+
+	return NullTimeOfDay{TimeOfDay: v, Valid: true}, nil
+}
+
+// UnwrapTimeOfDayProto unwraps the NullTimeOfDay to a google.type.TimeOfDay pointer.
+// If the value is null the function will return nil.
+// A google.type.TimeOfDay is independent of time zones, so the offset of a TIMETZ value is dropped.
+func UnwrapTimeOfDayProto(n NullTimeOfDay) *timeofday.TimeOfDay {
+	if !n.Valid {
+		return nil
+	}
+
+	t := n.TimeOfDay
+
+	return &timeofday.TimeOfDay{Hours: int32(t.Hour), Minutes: int32(t.Minute), Seconds: int32(t.Second), Nanos: int32(t.Microsecond) * 1000}
+}
+
+// nanosPerUnit is the number of nanos in a unit of a google.type.Money.
+const nanosPerUnit = 1000000000
+
+// NullMoney splits a google.type.Money pointer into the pair of columns it is stored in, an exact NUMERIC amount and a CHAR(3) currency code.
+// A nil money is converted to a pair of nulls.
+// Invalid nanos return an error wrapping ErrInvalidFormat, as do currency codes that are not ISO 4217 codes.
+func NullMoney(m *money.Money) (amount NullNumeric, currency sql.NullString, err error) {
+	if m == nil {
+		return NullNumeric{}, sql.NullString{}, nil
+	}
+
+	units, nanos := m.GetUnits(), m.GetNanos()
+
+	// The nanos must be within ±999,999,999 and have the sign of the units.
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit || (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return NullNumeric{}, sql.NullString{}, conversionError(m, "NullNumeric", ErrInvalidFormat, nil)
+	}
+
+	if !isCurrencyCode(m.GetCurrencyCode()) {
+		return NullNumeric{}, sql.NullString{}, conversionError(m, "sql.NullString", ErrInvalidFormat, fmt.Errorf("'%s' is not an ISO 4217 currency code", m.GetCurrencyCode()))
+	}
+
+	total := new(big.Int).Mul(big.NewInt(units), big.NewInt(nanosPerUnit))
+	total.Add(total, big.NewInt(int64(nanos)))
+
+	amount = NullNumeric{Rat: new(big.Rat).SetFrac(total, big.NewInt(nanosPerUnit)), Valid: true}
+
+	return amount, sql.NullString{String: m.GetCurrencyCode(), Valid: true}, nil
+}
+
+// UnwrapMoney recombines the amount and currency code columns into a google.type.Money pointer.
+// If both values are null the function will return nil, if only one of them is it returns an error wrapping ErrNull.
+// Currency codes that are not ISO 4217 codes return an error wrapping ErrInvalidFormat,
+// amounts with more than nine fractional digits an error wrapping ErrInexact and amounts beyond the range of the units an error wrapping ErrOverflow.
+func UnwrapMoney(amount NullNumeric, currency sql.NullString) (*money.Money, error) {
+	const source = "NullNumeric and sql.NullString"
+
+	switch {
+	case !amount.Valid && !currency.Valid:
+		return nil, nil
+	case !amount.Valid:
+		return nil, &ConversionError{Source: source, Target: "*money.Money", Err: fmt.Errorf("%w: amount is null but currency is not", ErrNull)}
+	case !currency.Valid:
+		return nil, &ConversionError{Source: source, Target: "*money.Money", Err: fmt.Errorf("%w: currency is null but amount is not", ErrNull)}
+	}
+
+	if !isCurrencyCode(currency.String) {
+		return nil, conversionError(currency.String, "*money.Money", ErrInvalidFormat, fmt.Errorf("'%s' is not an ISO 4217 currency code", currency.String))
+	}
+
+	total := new(big.Rat).Mul(amount.number(), new(big.Rat).SetInt64(nanosPerUnit))
+	if !total.IsInt() {
+		return nil, conversionError(amount.Rat, "*money.Money", ErrInexact, nil)
+	}
+
+	// Quo truncates towards zero, so the nanos have the sign of the units.
+	units, nanos := new(big.Int).QuoRem(total.Num(), big.NewInt(nanosPerUnit), new(big.Int))
+	if !units.IsInt64() {
+		return nil, conversionError(amount.Rat, "*money.Money", ErrOverflow, nil)
+	}
+
+	return &money.Money{CurrencyCode: currency.String, Units: units.Int64(), Nanos: int32(nanos.Int64())}, nil
+}
+
+// IntegerWrapper is the set of protobuf integer wrapper types.
+type IntegerWrapper interface {
+	*wrapperspb.Int64Value | *wrapperspb.Int32Value | *wrapperspb.UInt64Value | *wrapperspb.UInt32Value
+}
+
+// FloatWrapper is the set of protobuf floating point wrapper types.
+type FloatWrapper interface {
+	*wrapperspb.DoubleValue | *wrapperspb.FloatValue
+}
+
+// NullStringFromWrapper converts a wrapperspb.StringValue pointer to a sql.NullString type.
+// A nil wrapper is converted to null.
+func NullStringFromWrapper(s *wrapperspb.StringValue) sql.NullString {
+	return NullString(wrapped(s != nil, s.GetValue()))
+}
+
+// NullInt64FromWrapper converts a wrapperspb.Int64Value pointer to a sql.NullInt64 type.
+// A nil wrapper is converted to null.
+func NullInt64FromWrapper(i *wrapperspb.Int64Value) sql.NullInt64 {
+	return NullInt64(wrapped(i != nil, i.GetValue()))
+}
+
+// NullInt32FromWrapper converts a wrapperspb.Int32Value pointer to a sql.NullInt32 type.
+// A nil wrapper is converted to null.
+func NullInt32FromWrapper(i *wrapperspb.Int32Value) sql.NullInt32 {
+	return NullInt32(wrapped(i != nil, i.GetValue()))
+}
+
+// NullFloat64FromWrapper converts a wrapperspb.DoubleValue or wrapperspb.FloatValue pointer to a sql.NullFloat64 type.
+// A nil wrapper is converted to null.
+func NullFloat64FromWrapper[W FloatWrapper](f W) sql.NullFloat64 {
+	switch v := any(f).(type) {
+	case *wrapperspb.DoubleValue:
+		return NullFloat64(wrapped(v != nil, v.GetValue()))
+	case *wrapperspb.FloatValue:
+		return NullFloat64(wrapped(v != nil, float64(v.GetValue())))
+	}
+
+	return sql.NullFloat64{}
+}
+
+// NullFloat32FromWrapper converts a wrapperspb.FloatValue pointer to a sql.Null[float32] type.
+// A nil wrapper is converted to null.
+func NullFloat32FromWrapper(f *wrapperspb.FloatValue) sql.Null[float32] {
+	return NullFloat32(wrapped(f != nil, f.GetValue()))
+}
+
+// NullBooleanFromWrapper converts a wrapperspb.BoolValue pointer to a sql.NullBool type.
+// A nil wrapper is converted to null.
+func NullBooleanFromWrapper(b *wrapperspb.BoolValue) sql.NullBool {
+	return NullBoolean(wrapped(b != nil, b.GetValue()))
+}
+
+// NullUint64FromWrapper converts a wrapperspb.UInt64Value pointer to a NullUint64 type.
+// A nil wrapper is converted to null.
+func NullUint64FromWrapper(i *wrapperspb.UInt64Value) NullUint64 {
+	return ToNullUint64(NullPtr(wrapped(i != nil, i.GetValue())))
+}
+
+// BytesFromWrapper converts a wrapperspb.BytesValue pointer to the byte slice stored in a BYTEA column.
+// A nil wrapper is converted to a nil slice, which is written as null.
+func BytesFromWrapper(b *wrapperspb.BytesValue) []byte {
+	if b == nil {
+		return nil
+	}
+
+	if b.Value == nil {
+		return []byte{}
+	}
+
+	return b.Value
+}
+
+// NullBytesFromWrapper converts a wrapperspb.BytesValue pointer to a NullBytes type.
+// A nil wrapper is converted to null, a wrapper holding no bytes to an empty value.
+func NullBytesFromWrapper(b *wrapperspb.BytesValue) NullBytes {
+	if b == nil {
+		return NullBytes{}
+	}
+
+	return NullBytesOf(&b.Value)
+}
+
+// NullInt64OfWrapper converts any protobuf integer wrapper to a sql.NullInt64 type.
+// Values of a wrapperspb.UInt64Value above math.MaxInt64 return an error wrapping ErrOverflow.
+func NullInt64OfWrapper[W IntegerWrapper](i W) (sql.NullInt64, error) {
+	n, err := integerFromWrapper[int64](i)
+
+	return ToNullInt64(n), err
+}
+
+// NullInt32OfWrapper converts any protobuf integer wrapper to a sql.NullInt32 type.
+// Values outside the range of an int32, e.g. of a wrapperspb.Int64Value, return an error wrapping ErrOverflow.
+func NullInt32OfWrapper[W IntegerWrapper](i W) (sql.NullInt32, error) {
+	n, err := integerFromWrapper[int32](i)
+
+	return ToNullInt32(n), err
+}
+
+// NullInt16OfWrapper converts any protobuf integer wrapper to a sql.NullInt16 type.
+// Protobuf has no 16 bit integer, so SMALLINT columns are usually exposed as a wrapperspb.Int32Value.
+// Values outside the range of an int16 return an error wrapping ErrOverflow.
+func NullInt16OfWrapper[W IntegerWrapper](i W) (sql.NullInt16, error) {
+	n, err := integerFromWrapper[int16](i)
+
+	return ToNullInt16(n), err
+}
+
+// NullUint64OfWrapper converts any protobuf integer wrapper to a NullUint64 type.
+// Negative values return an error wrapping ErrOverflow.
+func NullUint64OfWrapper[W IntegerWrapper](i W) (NullUint64, error) {
+	n, err := integerFromWrapper[uint64](i)
+
+	return ToNullUint64(n), err
+}
+
+// wrapped returns a pointer to the value of a wrapper, or nil if the wrapper is nil.
+func wrapped[T any](valid bool, v T) *T {
+	if !valid {
+		return nil
+	}
+
+	return &v
+}
+
+// integerFromWrapper converts the value of the integer wrapper to a sql.Null[T] type, range checking the value.
+func integerFromWrapper[T Integer, W IntegerWrapper](w W) (sql.Null[T], error) {
+	switch v := any(w).(type) {
+	case *wrapperspb.Int64Value:
+		return convertIntegerPtr[T](wrapped(v != nil, v.GetValue()))
+	case *wrapperspb.Int32Value:
+		return convertIntegerPtr[T](wrapped(v != nil, v.GetValue()))
+	case *wrapperspb.UInt64Value:
+		return convertIntegerPtr[T](wrapped(v != nil, v.GetValue()))
+	case *wrapperspb.UInt32Value:
+		return convertIntegerPtr[T](wrapped(v != nil, v.GetValue()))
+	}
+
+	return sql.Null[T]{}, nil
+}
+
+// UnwrapStringValue unwraps the sql.NullString to a wrapperspb.StringValue pointer.
+// If the value is null the function will return nil.
+func UnwrapStringValue(s sql.NullString) *wrapperspb.StringValue {
+	if !s.Valid {
+		return nil
+	}
+
+	return wrapperspb.String(s.String)
+}
+
+// UnwrapInt64Value unwraps any sql null integer type to a wrapperspb.Int64Value pointer.
+// If the value is null the function will return nil.
+func UnwrapInt64Value[N NullInteger](i N) *wrapperspb.Int64Value {
+	// Every sql null integer type fits in an int64.
+	v, _ := UnwrapInteger[int64](i)
+	if v == nil {
+		return nil
+	}
+
+	return wrapperspb.Int64(*v)
+}
+
+// UnwrapInt32Value unwraps the sql.NullInt32 or sql.NullInt16 to a wrapperspb.Int32Value pointer.
+// If the value is null the function will return nil.
+// Use UnwrapInt32ValueOf to range check a sql.NullInt64.
+func UnwrapInt32Value[N sql.NullInt32 | sql.NullInt16](i N) *wrapperspb.Int32Value {
+	v, _ := UnwrapInt32ValueOf(i)
+
+	return v
+}
+
+// UnwrapInt32ValueOf unwraps any sql null integer type to a wrapperspb.Int32Value pointer.
+// If the value is null the function will return nil.
+// Values outside the range of an int32 return an error wrapping ErrOverflow.
+func UnwrapInt32ValueOf[N NullInteger](i N) (*wrapperspb.Int32Value, error) {
+	v, err := UnwrapInteger[int32](i)
+	if v == nil {
+		return nil, err
+	}
+
+	return wrapperspb.Int32(*v), nil
+}
+
+// UnwrapDoubleValue unwraps the sql.NullFloat64 to a wrapperspb.DoubleValue pointer.
+// If the value is null the function will return nil.
+func UnwrapDoubleValue(f sql.NullFloat64) *wrapperspb.DoubleValue {
+	if !f.Valid {
+		return nil
+	}
+
+	return wrapperspb.Double(f.Float64)
+}
+
+// UnwrapFloatValue unwraps the sql.NullFloat64 to a wrapperspb.FloatValue pointer.
+// If the value is null the function will return nil.
+// Finite values outside the range of a float32 return an error wrapping ErrOverflow, smaller values are rounded.
+func UnwrapFloatValue(f sql.NullFloat64) (*wrapperspb.FloatValue, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+
+	v, err := UnwrapReal(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapperspb.Float(*v), nil
+}
+
+// UnwrapFloat32Value unwraps the sql.Null[float32] to a wrapperspb.FloatValue pointer.
+// If the value is null the function will return nil.
+func UnwrapFloat32Value(f sql.Null[float32]) *wrapperspb.FloatValue {
+	if !f.Valid {
+		return nil
+	}
+
+	return wrapperspb.Float(f.V)
+}
+
+// UnwrapBoolValue unwraps the sql.NullBool to a wrapperspb.BoolValue pointer.
+// If the value is null the function will return nil.
+func UnwrapBoolValue(b sql.NullBool) *wrapperspb.BoolValue {
+	if !b.Valid {
+		return nil
+	}
+
+	return wrapperspb.Bool(b.Bool)
+}
+
+// UnwrapUInt64Value unwraps the NullUint64 to a wrapperspb.UInt64Value pointer.
+// If the value is null the function will return nil.
+func UnwrapUInt64Value(i NullUint64) *wrapperspb.UInt64Value {
+	if !i.Valid {
+		return nil
+	}
+
+	return wrapperspb.UInt64(i.Uint64)
+}
+
+// UnwrapUInt64ValueOf unwraps any sql null integer type to a wrapperspb.UInt64Value pointer.
+// If the value is null the function will return nil.
+// Negative values return an error wrapping ErrOverflow.
+func UnwrapUInt64ValueOf[N NullInteger](i N) (*wrapperspb.UInt64Value, error) {
+	v, err := UnwrapInteger[uint64](i)
+	if v == nil {
+		return nil, err
+	}
+
+	return wrapperspb.UInt64(*v), nil
+}
+
+// UnwrapBytesValue wraps the byte slice of a BYTEA column in a wrapperspb.BytesValue pointer.
+// If the slice is nil, as scanned from null, the function will return nil.
+func UnwrapBytesValue(b []byte) *wrapperspb.BytesValue {
+	if b == nil {
+		return nil
+	}
+
+	return wrapperspb.Bytes(b)
+}
 
-	if tm.IsZero() {
+// UnwrapNullBytesValue wraps the NullBytes in a wrapperspb.BytesValue pointer.
+// If the value is null the function will return nil.
+func UnwrapNullBytesValue(n NullBytes) *wrapperspb.BytesValue {
+	if !n.Valid {
 		return nil
 	}
 
-	return timestamppb.New(tm)
+	return wrapperspb.Bytes(UnwrapBytes(n))
 }
@@ -9,10 +9,18 @@ package sqlmap
 
 import (
 	"database/sql"
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 	"time"
 
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestNullTimeFromTimestamp(t *testing.T) {
@@ -53,6 +61,41 @@ func TestNullTimeFromTimestamp(t *testing.T) {
 	}
 }
 
+func TestNullTimeFromTimestampStrict(t *testing.T) {
+	t.Run("successfully convert valid timestamp", func(t *testing.T) {
+		ts := timestamppb.Now()
+
+		result, err := NullTimeFromTimestampStrict(ts)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if !result.Valid || !result.Time.Equal(ts.AsTime()) {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, ts.AsTime())
+		}
+	})
+
+	t.Run("successfully convert nil to null", func(t *testing.T) {
+		result, err := NullTimeFromTimestampStrict(nil)
+		if err != nil || result.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", result, err)
+		}
+	})
+
+	t.Run("should reject invalid timestamp", func(t *testing.T) {
+		for _, ts := range []*timestamppb.Timestamp{{Seconds: -99999999999}, {Nanos: -1}} {
+			result, err := NullTimeFromTimestampStrict(ts)
+			if !errors.Is(err, ErrInvalidTimestamp) {
+				t.Fatalf("error should wrap ErrInvalidTimestamp, got: '%v'", err)
+			}
+
+			if result.Valid {
+				t.Fatalf("result should be invalid, got: '%v'", result)
+			}
+		}
+	})
+}
+
 func TestUnwrapTimestamp(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -103,3 +146,499 @@ func TestUnwrapTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestUnwrapTimestampZeroTime(t *testing.T) {
+	t.Run("should unwrap valid zero time to timestamp", func(t *testing.T) {
+		result := UnwrapTimestamp(sql.NullTime{Valid: true})
+
+		if result == nil || !result.AsTime().Equal(time.Time{}) {
+			t.Fatalf("result should be the zero time, got: '%v'", result)
+		}
+	})
+
+	t.Run("should round trip zero time through NullTimeFromTimestamp", func(t *testing.T) {
+		input := sql.NullTime{Valid: true}
+
+		result := NullTimeFromTimestamp(UnwrapTimestamp(input))
+
+		if !result.Valid || !result.Time.Equal(input.Time) {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, input)
+		}
+	})
+
+	t.Run("should unwrap null zero time to nil", func(t *testing.T) {
+		if result := UnwrapTimestamp(sql.NullTime{}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+	})
+}
+
+func TestUnwrapTimestampOr(t *testing.T) {
+	fallback := timestamppb.New(time.Unix(42, 0))
+
+	t.Run("should return value of valid sql.NullTime", func(t *testing.T) {
+		now := time.Now()
+
+		result := UnwrapTimestampOr(sql.NullTime{Time: now, Valid: true}, fallback)
+
+		if !result.AsTime().Equal(now) {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result.AsTime(), now)
+		}
+	})
+
+	t.Run("should return fallback of invalid sql.NullTime with value", func(t *testing.T) {
+		if result := UnwrapTimestampOr(sql.NullTime{Time: time.Now()}, fallback); result != fallback {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, fallback)
+		}
+	})
+
+	t.Run("should return zero timestamp of invalid sql.NullTime with value", func(t *testing.T) {
+		result := UnwrapTimestampOrZero(sql.NullTime{Time: time.Now()})
+
+		if result == nil || !result.AsTime().Equal(time.Unix(0, 0)) {
+			t.Fatalf("result should be the zero timestamp, got: '%v'", result)
+		}
+	})
+}
+
+func TestNullFromWrapper(t *testing.T) {
+	t.Run("successfully convert wrapper values", func(t *testing.T) {
+		compareString(t, ptr("foo"), NullStringFromWrapper(wrapperspb.String("foo")))
+		compareInt64(t, ptr[int64](math.MaxInt64), NullInt64FromWrapper(wrapperspb.Int64(math.MaxInt64)))
+		compareInt32(t, ptr[int32](-42), NullInt32FromWrapper(wrapperspb.Int32(-42)))
+		compareFloat64(t, ptr(4.2), NullFloat64FromWrapper(wrapperspb.Double(4.2)))
+		compareFloat64(t, ptr(0.5), NullFloat64FromWrapper(wrapperspb.Float(0.5)))
+		compareBoolean(t, ptr(false), NullBooleanFromWrapper(wrapperspb.Bool(false)))
+
+		if result := NullUint64FromWrapper(wrapperspb.UInt64(math.MaxUint64)); !result.Valid || result.Uint64 != math.MaxUint64 {
+			t.Fatalf("result mismatch got '%v', expected: '%d'", result, uint64(math.MaxUint64))
+		}
+	})
+
+	t.Run("successfully convert nil wrappers to null", func(t *testing.T) {
+		compareString(t, nil, NullStringFromWrapper(nil))
+		compareInt64(t, nil, NullInt64FromWrapper(nil))
+		compareInt32(t, nil, NullInt32FromWrapper(nil))
+		compareFloat64(t, nil, NullFloat64FromWrapper[*wrapperspb.DoubleValue](nil))
+		compareFloat64(t, nil, NullFloat64FromWrapper[*wrapperspb.FloatValue](nil))
+		compareBoolean(t, nil, NullBooleanFromWrapper(nil))
+
+		if result := NullUint64FromWrapper(nil); result.Valid {
+			t.Fatalf("result should be invalid, got: '%v'", result)
+		}
+	})
+
+	t.Run("should distinguish empty bytes from null", func(t *testing.T) {
+		if result := BytesFromWrapper(nil); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := BytesFromWrapper(&wrapperspb.BytesValue{}); result == nil || len(result) != 0 {
+			t.Fatalf("result should be empty, got: '%v'", result)
+		}
+	})
+}
+
+func TestNullOfWrapper(t *testing.T) {
+	t.Run("successfully convert values in range", func(t *testing.T) {
+		i16, err := NullInt16OfWrapper(wrapperspb.Int32(math.MaxInt16))
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt16(t, ptr[int16](math.MaxInt16), i16)
+
+		i32, err := NullInt32OfWrapper(wrapperspb.Int64(math.MinInt32))
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt32(t, ptr[int32](math.MinInt32), i32)
+	})
+
+	t.Run("successfully convert nil to null", func(t *testing.T) {
+		i32, err := NullInt32OfWrapper[*wrapperspb.Int64Value](nil)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		compareInt32(t, nil, i32)
+	})
+
+	t.Run("should reject values out of range", func(t *testing.T) {
+		if _, err := NullInt32OfWrapper(wrapperspb.Int64(math.MaxInt32 + 1)); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+
+		if _, err := NullInt16OfWrapper(wrapperspb.UInt32(math.MaxInt16 + 1)); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+
+		if _, err := NullInt64OfWrapper(wrapperspb.UInt64(math.MaxUint64)); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+
+		if _, err := NullUint64OfWrapper(wrapperspb.Int64(-1)); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
+
+func TestUnwrapWrapperValue(t *testing.T) {
+	t.Run("successfully unwrap valid values", func(t *testing.T) {
+		if result := UnwrapStringValue(sql.NullString{String: "foo", Valid: true}); result.GetValue() != "foo" {
+			t.Fatalf("result mismatch got '%v', expected: 'foo'", result)
+		}
+
+		if result := UnwrapInt64Value(sql.NullInt16{Int16: -42, Valid: true}); result.GetValue() != -42 {
+			t.Fatalf("result mismatch got '%v', expected: '-42'", result)
+		}
+
+		if result := UnwrapInt32Value(sql.NullInt32{Int32: 42, Valid: true}); result.GetValue() != 42 {
+			t.Fatalf("result mismatch got '%v', expected: '42'", result)
+		}
+
+		if result := UnwrapDoubleValue(sql.NullFloat64{Float64: 4.2, Valid: true}); result.GetValue() != 4.2 {
+			t.Fatalf("result mismatch got '%v', expected: '4.2'", result)
+		}
+
+		if result := UnwrapBoolValue(sql.NullBool{Valid: true}); result == nil || result.GetValue() {
+			t.Fatalf("result mismatch got '%v', expected: 'false'", result)
+		}
+
+		if result := UnwrapUInt64Value(NullUint64{Uint64: math.MaxUint64, Valid: true}); result.GetValue() != math.MaxUint64 {
+			t.Fatalf("result mismatch got '%v', expected: '%d'", result, uint64(math.MaxUint64))
+		}
+
+		if result := UnwrapBytesValue([]byte{}); result == nil {
+			t.Fatalf("result should not be nil")
+		}
+	})
+
+	t.Run("successfully unwrap null values to nil", func(t *testing.T) {
+		if result := UnwrapStringValue(sql.NullString{String: "foo"}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapInt64Value(sql.NullInt64{Int64: 42}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapInt32Value(sql.NullInt16{Int16: 42}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapDoubleValue(sql.NullFloat64{Float64: 4.2}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapBoolValue(sql.NullBool{Bool: true}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapUInt64Value(NullUint64{Uint64: 42}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+
+		if result := UnwrapBytesValue(nil); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+	})
+
+	t.Run("should range check narrowing conversions", func(t *testing.T) {
+		if _, err := UnwrapInt32ValueOf(sql.NullInt64{Int64: math.MaxInt32 + 1, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+
+		if _, err := UnwrapUInt64ValueOf(sql.NullInt64{Int64: -1, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+
+		if _, err := UnwrapFloatValue(sql.NullFloat64{Float64: math.MaxFloat64, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+
+		result, err := UnwrapFloatValue(sql.NullFloat64{Float64: 0.5, Valid: true})
+		if err != nil || result.GetValue() != 0.5 {
+			t.Fatalf("result mismatch got '%v', expected: '0.5'", result)
+		}
+	})
+}
+
+func TestNullIntervalFromDuration(t *testing.T) {
+	t.Run("successfully convert duration beyond the range of time.Duration", func(t *testing.T) {
+		d := &durationpb.Duration{Seconds: -315576000000, Nanos: -999999999}
+
+		result, err := NullIntervalFromDuration(d)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if !result.Valid || result.Interval.Microseconds != -315576000000999999 {
+			t.Fatalf("result mismatch got '%+v'", result)
+		}
+	})
+
+	t.Run("successfully convert nil to null", func(t *testing.T) {
+		if result, err := NullIntervalFromDuration(nil); err != nil || result.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject invalid duration", func(t *testing.T) {
+		if _, err := NullIntervalFromDuration(&durationpb.Duration{Seconds: 1, Nanos: -1}); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+		}
+	})
+}
+
+func TestUnwrapDuration(t *testing.T) {
+	t.Run("successfully unwrap interval", func(t *testing.T) {
+		result, err := UnwrapDuration(NullInterval{Interval: Interval{Days: -1, Microseconds: -1500000}, Valid: true})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result.GetSeconds() != -86401 || result.GetNanos() != -500000000 {
+			t.Fatalf("result mismatch got '%v'", result)
+		}
+	})
+
+	t.Run("should round trip duration", func(t *testing.T) {
+		d := durationpb.New(36*time.Hour + 250*time.Millisecond)
+
+		n, err := NullIntervalFromDuration(d)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result, err := UnwrapDuration(n); err != nil || result.AsDuration() != d.AsDuration() {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '%v'", result, err, d)
+		}
+	})
+
+	t.Run("successfully unwrap null to nil", func(t *testing.T) {
+		if result, err := UnwrapDuration(NullInterval{}); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject months", func(t *testing.T) {
+		if _, err := UnwrapDuration(NullInterval{Interval: Interval{Months: 1}, Valid: true}); !errors.Is(err, ErrNoFixedDuration) {
+			t.Fatalf("error should wrap ErrNoFixedDuration, got: '%v'", err)
+		}
+	})
+}
+
+func TestNullDateFromProto(t *testing.T) {
+	t.Run("successfully convert date", func(t *testing.T) {
+		result, err := NullDateFromProto(&date.Date{Year: 2024, Month: 2, Day: 29})
+		if err != nil || !result.Valid || result.Date != (Date{Year: 2024, Month: time.February, Day: 29}) {
+			t.Fatalf("result mismatch got '%+v' (%v)", result, err)
+		}
+	})
+
+	t.Run("successfully convert nil to null", func(t *testing.T) {
+		if result, err := NullDateFromProto(nil); err != nil || result.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject partial and invalid dates", func(t *testing.T) {
+		for _, d := range []*date.Date{{Month: 12, Day: 25}, {Year: 2024, Month: 2}, {Year: 2023, Month: 2, Day: 29}} {
+			if _, err := NullDateFromProto(d); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+			}
+		}
+	})
+}
+
+func TestUnwrapDateProto(t *testing.T) {
+	t.Run("successfully unwrap date", func(t *testing.T) {
+		result, err := UnwrapDateProto(NullDate{Date: Date{Year: 2024, Month: time.February, Day: 29}, Valid: true})
+		if err != nil || result.GetYear() != 2024 || result.GetMonth() != 2 || result.GetDay() != 29 {
+			t.Fatalf("result mismatch got '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("successfully unwrap null to nil", func(t *testing.T) {
+		if result, err := UnwrapDateProto(NullDate{}); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject years out of range", func(t *testing.T) {
+		if _, err := UnwrapDateProto(NullDate{Date: Date{Year: 10000, Month: time.January, Day: 1}, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
+
+func TestNullTimeOfDayFromProto(t *testing.T) {
+	t.Run("successfully convert time of day", func(t *testing.T) {
+		result, err := NullTimeOfDayFromProto(&timeofday.TimeOfDay{Hours: 13, Minutes: 30, Seconds: 15, Nanos: 1500})
+		if err != nil || !result.Valid || result.TimeOfDay != (TimeOfDay{Hour: 13, Minute: 30, Second: 15, Microsecond: 1}) {
+			t.Fatalf("result mismatch got '%+v' (%v)", result, err)
+		}
+	})
+
+	t.Run("successfully convert nil to null", func(t *testing.T) {
+		if result, err := NullTimeOfDayFromProto(nil); err != nil || result.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject invalid time of day", func(t *testing.T) {
+		for _, tod := range []*timeofday.TimeOfDay{{Hours: 25}, {Hours: 23, Seconds: 60}, {Nanos: -1}} {
+			if _, err := NullTimeOfDayFromProto(tod); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+			}
+		}
+	})
+}
+
+func TestUnwrapTimeOfDayProto(t *testing.T) {
+	result := UnwrapTimeOfDayProto(NullTimeOfDay{TimeOfDay: TimeOfDay{Hour: 9, Microsecond: 2, Offset: 3600, HasOffset: true}, Valid: true})
+	if result.GetHours() != 9 || result.GetNanos() != 2000 {
+		t.Fatalf("result mismatch got '%v'", result)
+	}
+
+	if result := UnwrapTimeOfDayProto(NullTimeOfDay{}); result != nil {
+		t.Fatalf("result should be nil, got: '%v'", result)
+	}
+}
+
+func TestNullMoney(t *testing.T) {
+	t.Run("successfully split money", func(t *testing.T) {
+		amount, currency, err := NullMoney(&money.Money{CurrencyCode: "USD", Units: -1, Nanos: -750000000})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if amount.String() != "-1.75" {
+			t.Fatalf("result mismatch got '%v', expected: '-1.75'", amount)
+		}
+
+		compareString(t, ptr("USD"), currency)
+	})
+
+	t.Run("successfully split nil money into nulls", func(t *testing.T) {
+		amount, currency, err := NullMoney(nil)
+		if err != nil || amount.Valid || currency.Valid {
+			t.Fatalf("result should be null, got: '%v', '%v' (%v)", amount, currency, err)
+		}
+	})
+
+	t.Run("should reject invalid money", func(t *testing.T) {
+		for _, m := range []*money.Money{
+			{CurrencyCode: "USD", Units: 1, Nanos: -1},
+			{CurrencyCode: "USD", Nanos: 1000000000},
+			{CurrencyCode: "usd", Units: 1},
+			{CurrencyCode: "ABC", Units: 1},
+			{Units: 1},
+		} {
+			if _, _, err := NullMoney(m); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat for '%v', got: '%v'", m, err)
+			}
+		}
+	})
+}
+
+func TestUnwrapMoney(t *testing.T) {
+	usd := sql.NullString{String: "USD", Valid: true}
+
+	t.Run("successfully recombine money", func(t *testing.T) {
+		result, err := UnwrapMoney(NullDecimal(big.NewRat(-7, 4)), usd)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result.GetCurrencyCode() != "USD" || result.GetUnits() != -1 || result.GetNanos() != -750000000 {
+			t.Fatalf("result mismatch got '%v'", result)
+		}
+	})
+
+	t.Run("should round trip money", func(t *testing.T) {
+		m := &money.Money{CurrencyCode: "JPY", Units: math.MaxInt64, Nanos: 999999999}
+
+		amount, currency, err := NullMoney(m)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		result, err := UnwrapMoney(amount, currency)
+		if err != nil || result.GetUnits() != m.GetUnits() || result.GetNanos() != m.GetNanos() || result.GetCurrencyCode() != m.GetCurrencyCode() {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '%v'", result, err, m)
+		}
+	})
+
+	t.Run("successfully unwrap valid amount without rat as zero", func(t *testing.T) {
+		result, err := UnwrapMoney(NullNumeric{Valid: true}, usd)
+		if err != nil || result.GetUnits() != 0 || result.GetNanos() != 0 || result.GetCurrencyCode() != "USD" {
+			t.Fatalf("result mismatch got '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("successfully unwrap pair of nulls to nil", func(t *testing.T) {
+		if result, err := UnwrapMoney(NullNumeric{}, sql.NullString{}); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should reject a single null", func(t *testing.T) {
+		if _, err := UnwrapMoney(NullNumeric{}, usd); !errors.Is(err, ErrNull) {
+			t.Fatalf("error should wrap ErrNull, got: '%v'", err)
+		}
+
+		if _, err := UnwrapMoney(NullDecimal(big.NewRat(1, 1)), sql.NullString{}); !errors.Is(err, ErrNull) {
+			t.Fatalf("error should wrap ErrNull, got: '%v'", err)
+		}
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		if _, err := UnwrapMoney(NullDecimal(big.NewRat(1, 1)), sql.NullString{String: "XYZ", Valid: true}); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+		}
+
+		if _, err := UnwrapMoney(NullDecimal(big.NewRat(1, 3)), usd); !errors.Is(err, ErrInexact) {
+			t.Fatalf("error should wrap ErrInexact, got: '%v'", err)
+		}
+
+		huge := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 64))
+		if _, err := UnwrapMoney(NullDecimal(huge), usd); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
+
+func TestNullBytesWrapper(t *testing.T) {
+	if result := NullBytesFromWrapper(nil); result.Valid {
+		t.Fatalf("nil wrapper should be null, got: '%v'", result)
+	}
+
+	if result := NullBytesFromWrapper(&wrapperspb.BytesValue{}); !result.Valid || result.Bytes == nil {
+		t.Fatalf("empty wrapper should be an empty value, got: '%#v'", result)
+	}
+
+	if result := UnwrapNullBytesValue(NullBytes{}); result != nil {
+		t.Fatalf("result should be nil, got: '%v'", result)
+	}
+
+	result := UnwrapNullBytesValue(NullBytes{Valid: true})
+	if result == nil || len(result.GetValue()) != 0 {
+		t.Fatalf("result should be an empty wrapper, got: '%v'", result)
+	}
+}
+
+func TestFloat32Wrapper(t *testing.T) {
+	compareNull(t, nil, NullFloat32FromWrapper(nil))
+	compareNull(t, ptr[float32](1.5), NullFloat32FromWrapper(wrapperspb.Float(1.5)))
+
+	if result := UnwrapFloat32Value(sql.Null[float32]{V: 1.5}); result != nil {
+		t.Fatalf("result should be nil, got: '%v'", result)
+	}
+
+	if result := UnwrapFloat32Value(sql.Null[float32]{V: 1.5, Valid: true}); result.GetValue() != 1.5 {
+		t.Fatalf("result mismatch got '%v', expected: '%v'", result, 1.5)
+	}
+}
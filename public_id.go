@@ -0,0 +1,228 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultAlphabet is the alphabet used by an IDEncoder when none is configured.
+const DefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// minAlphabetLength is the length below which an alphabet cannot produce reasonably short ids.
+const minAlphabetLength = 16
+
+// IDEncoder turns serials into short opaque strings and back, in the style of Hashids,
+// so sequential SERIAL values are not exposed in URLs.
+// The encoding is obfuscation, not encryption: keep the salt private but do not rely on it for access control.
+type IDEncoder struct {
+	alphabet  []byte
+	salt      []byte
+	minLength int
+}
+
+// NewIDEncoder returns an IDEncoder using the alphabet shuffled by the salt.
+// The alphabet must hold at least 16 unique ASCII characters, DefaultAlphabet is used if it is empty.
+// Encoded ids are padded to at least minLength characters.
+func NewIDEncoder(alphabet, salt string, minLength int) (*IDEncoder, error) {
+	if alphabet == "" {
+		alphabet = DefaultAlphabet
+	}
+
+	if len(alphabet) < minAlphabetLength {
+		return nil, fmt.Errorf("sqlmap: alphabet must hold at least %d characters", minAlphabetLength)
+	}
+
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+
+		if c <= ' ' || c > '~' {
+			return nil, fmt.Errorf("sqlmap: alphabet must only hold printable ASCII characters, got '%c'", c)
+		}
+
+		if strings.IndexByte(alphabet[i+1:], c) >= 0 {
+			return nil, fmt.Errorf("sqlmap: alphabet must hold unique characters, got '%c' twice", c)
+		}
+	}
+
+	if minLength < 0 {
+		return nil, fmt.Errorf("sqlmap: minimum length must not be negative")
+	}
+
+	return &IDEncoder{
+		alphabet:  shuffle([]byte(alphabet), []byte(salt)),
+		salt:      []byte(salt),
+		minLength: minLength,
+	}, nil
+}
+
+// Encode returns the opaque string representation of the serial.
+func (e *IDEncoder) Encode(id uint64) string {
+	n := uint64(len(e.alphabet))
+	lottery := e.alphabet[id%n]
+
+	// The alphabet is shuffled again for every id, the first character is kept as separator for the padding.
+	alphabet := e.shuffled(lottery)
+	sep, digits := alphabet[0], alphabet[1:]
+	base := uint64(len(digits))
+
+	var body []byte
+
+	for v := id; ; v /= base {
+		body = append(body, digits[v%base])
+
+		if v < base {
+			break
+		}
+	}
+
+	out := make([]byte, 0, max(e.minLength, len(body)+1))
+	out = append(out, lottery)
+
+	for i := len(body) - 1; i >= 0; i-- {
+		out = append(out, body[i])
+	}
+
+	if len(out) < e.minLength {
+		out = append(out, sep)
+
+		for i := 0; len(out) < e.minLength; i++ {
+			out = append(out, digits[(id+uint64(i))%base])
+		}
+	}
+
+	return string(out)
+}
+
+// Decode returns the serial encoded in the opaque string.
+// Strings that were not produced by Encode with the same configuration are rejected.
+func (e *IDEncoder) Decode(in string) (uint64, error) {
+	id, ok := e.decode(in)
+	if !ok {
+		return 0, conversionError(in, "public id", ErrInvalidFormat, nil)
+	}
+
+	return id, nil
+}
+
+// decode parses the opaque string, reporting whether it is the canonical encoding of the id.
+func (e *IDEncoder) decode(in string) (uint64, bool) {
+	if len(in) < 2 || bytes.IndexByte(e.alphabet, in[0]) < 0 {
+		return 0, false
+	}
+
+	alphabet := e.shuffled(in[0])
+	sep, digits := alphabet[0], alphabet[1:]
+	base := uint64(len(digits))
+
+	body, _, _ := strings.Cut(in[1:], string(sep))
+
+	var id uint64
+
+	for i := 0; i < len(body); i++ {
+		d := bytes.IndexByte(digits, body[i])
+		if d < 0 || id > (math.MaxUint64-uint64(d))/base {
+			return 0, false
+		}
+
+		id = id*base + uint64(d)
+	}
+
+	// Only the canonical encoding is accepted, so every id has exactly one representation.
+	return id, e.Encode(id) == in
+}
+
+// shuffled returns the alphabet shuffled for the lottery character.
+func (e *IDEncoder) shuffled(lottery byte) []byte {
+	key := append([]byte{lottery}, e.salt...)
+
+	return shuffle(bytes.Clone(e.alphabet), key)
+}
+
+// shuffle deterministically shuffles the alphabet in place using the key, as Hashids does.
+func shuffle(alphabet, key []byte) []byte {
+	if len(key) == 0 {
+		return alphabet
+	}
+
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i-- {
+		v %= len(key)
+		n := int(key[v])
+		p += n
+		j := (n + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+		v++
+	}
+
+	return alphabet
+}
+
+// IDScheme provides the IDEncoder of a PublicID.
+// Implement it on an empty struct type per entity, so each table can use its own salt.
+type IDScheme interface {
+	IDEncoder() *IDEncoder
+}
+
+// PublicID is a serial exposed as an opaque string encoded by the IDEncoder of the scheme S.
+// It implements sql.Scanner and driver.Valuer, so the encoding happens when scanning and writing the column.
+// The empty PublicID is written as NULL and NULL is scanned as the empty PublicID.
+//
+//	type userIDs struct{}
+//
+//	func (userIDs) IDEncoder() *sqlmap.IDEncoder { return userIDEncoder }
+//
+//	type User struct {
+//		ID sqlmap.PublicID[userIDs] `json:"id"`
+//	}
+type PublicID[S IDScheme] string
+
+// Serial decodes the public id.
+func (p PublicID[S]) Serial() (uint64, error) {
+	var scheme S
+
+	return scheme.IDEncoder().Decode(string(p))
+}
+
+// Scan implements the sql.Scanner interface.
+func (p *PublicID[S]) Scan(value any) error {
+	var n NullUint64
+
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+
+	if !n.Valid {
+		*p = ""
+
+		return nil
+	}
+
+	var scheme S
+
+	*p = PublicID[S](scheme.IDEncoder().Encode(n.Uint64))
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (p PublicID[S]) Value() (driver.Value, error) {
+	if p == "" {
+		return nil, nil
+	}
+
+	id, err := p.Serial()
+	if err != nil {
+		return nil, err
+	}
+
+	return NullUint64{Uint64: id, Valid: true}.Value()
+}
@@ -0,0 +1,132 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"math"
+	"testing"
+)
+
+// testIDs is the IDScheme used to test PublicID.
+type testIDs struct{}
+
+var testIDEncoder, _ = NewIDEncoder("", "pepper", 6)
+
+func (testIDs) IDEncoder() *IDEncoder {
+	return testIDEncoder
+}
+
+func TestIDEncoder(t *testing.T) {
+	t.Run("successfully round trip serials", func(t *testing.T) {
+		for _, id := range []uint64{0, 1, 2, 61, 62, 63, 1000, 123456789, math.MaxInt64, math.MaxUint64} {
+			encoded := testIDEncoder.Encode(id)
+
+			if len(encoded) < 6 {
+				t.Fatalf("encoded id '%s' should be padded to 6 characters", encoded)
+			}
+
+			result, err := testIDEncoder.Decode(encoded)
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != id {
+				t.Fatalf("result mismatch got '%d', expected: '%d'", result, id)
+			}
+		}
+	})
+
+	t.Run("should not expose sequential serials", func(t *testing.T) {
+		if testIDEncoder.Encode(1) == testIDEncoder.Encode(2) || testIDEncoder.Encode(1)[1:] == testIDEncoder.Encode(2)[1:] {
+			t.Fatalf("consecutive serials should not share their encoding")
+		}
+	})
+
+	t.Run("should depend on the salt", func(t *testing.T) {
+		other, err := NewIDEncoder("", "salt", 6)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if other.Encode(42) == testIDEncoder.Encode(42) {
+			t.Fatalf("encodings with different salts should differ")
+		}
+
+		if _, err := other.Decode(testIDEncoder.Encode(123456789)); err == nil {
+			t.Fatalf("function should fail to decode id encoded with another salt")
+		}
+	})
+
+	t.Run("should fail to decode invalid strings", func(t *testing.T) {
+		encoded := testIDEncoder.Encode(42)
+
+		for _, input := range []string{"", "a", "!!!!!!", encoded + "x", encoded[:len(encoded)-1]} {
+			if _, err := testIDEncoder.Decode(input); err == nil {
+				t.Fatalf("function should fail to decode '%s'", input)
+			}
+		}
+	})
+}
+
+func TestNewIDEncoder(t *testing.T) {
+	testCases := []struct {
+		name      string
+		alphabet  string
+		minLength int
+	}{
+		{name: "should reject short alphabets", alphabet: "abcdef"},
+		{name: "should reject duplicate characters", alphabet: "abcdefghijklmnopa"},
+		{name: "should reject non printable characters", alphabet: "abcdefghijklmnop "},
+		{name: "should reject negative minimum length", alphabet: DefaultAlphabet, minLength: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewIDEncoder(tc.alphabet, "salt", tc.minLength); err == nil {
+				t.Fatalf("function should return error")
+			}
+		})
+	}
+}
+
+func TestPublicID(t *testing.T) {
+	t.Run("successfully scan and value round trip", func(t *testing.T) {
+		var p PublicID[testIDs]
+
+		if err := p.Scan(int64(42)); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if string(p) != testIDEncoder.Encode(42) {
+			t.Fatalf("result mismatch got '%s', expected: '%s'", p, testIDEncoder.Encode(42))
+		}
+
+		v, err := p.Value()
+		if err != nil || v != int64(42) {
+			t.Fatalf("value mismatch got '%v' ('%v'), expected: '%d'", v, err, 42)
+		}
+	})
+
+	t.Run("successfully scan and value NULL", func(t *testing.T) {
+		p := PublicID[testIDs]("foo")
+
+		if err := p.Scan(nil); err != nil || p != "" {
+			t.Fatalf("result should be empty without error, got: '%s', '%v'", p, err)
+		}
+
+		if v, err := p.Value(); v != nil || err != nil {
+			t.Fatalf("value should be nil without error, got: '%v', '%v'", v, err)
+		}
+	})
+
+	t.Run("should fail to value tampered ids", func(t *testing.T) {
+		if _, err := PublicID[testIDs]("tampered").Value(); err == nil {
+			t.Fatalf("function should return error")
+		}
+	})
+}
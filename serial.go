@@ -0,0 +1,204 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ParseSerial parses the string representation of a serial, a notational convenience for creating unique identifier columns.
+// It is an auto-incrementing integer starting from zero.
+// https://www.postgresql.org/docs/current/datatype-numeric.html#DATATYPE-SERIAL
+//
+// Invalid input returns a *ConversionError wrapping ErrInvalidFormat, or ErrOverflow, and the *strconv.NumError.
+func ParseSerial(in string) (uint64, error) {
+	return parseUint(in)
+}
+
+// parseUint parses the decimal representation of an unsigned integer.
+func parseUint(in string) (uint64, error) {
+	out, err := strconv.ParseUint(in, 10, 64)
+	if err != nil {
+		kind := ErrInvalidFormat
+		if errors.Is(err, strconv.ErrRange) {
+			kind = ErrOverflow
+		}
+
+		return 0, conversionError(in, "uint64", kind, err)
+	}
+
+	return out, nil
+}
+
+// SerialID is an identifier stored in a SERIAL or BIGSERIAL column.
+//
+// It is encoded as a decimal string in JSON and text, so identifiers above 2^53 survive JavaScript clients
+// and travel through protobuf string fields without manual parsing.
+type SerialID uint64
+
+// String returns the decimal representation of the serial, suitable for a protobuf string field.
+func (s SerialID) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Scan implements the sql.Scanner interface.
+// Use NullSerial to scan nullable columns.
+func (s *SerialID) Scan(value any) error {
+	var n NullSerial
+
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+
+	if !n.Valid {
+		return &ConversionError{Source: "NULL", Target: "SerialID", Err: fmt.Errorf("%w, use NullSerial", ErrNull)}
+	}
+
+	*s = n.Serial
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (s SerialID) Value() (driver.Value, error) {
+	return NullUint64{Uint64: uint64(s), Valid: true}.Value()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s SerialID) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *SerialID) UnmarshalText(text []byte) error {
+	v, err := ParseSerial(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = SerialID(v)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The serial is encoded as a quoted string.
+func (s SerialID) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, s.String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both quoted strings and plain numbers are accepted.
+// JSON null is a no-op, as for the other json.Unmarshaler implementations of the standard library.
+func (s *SerialID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	return s.UnmarshalText(unquote(data))
+}
+
+// NullSerial represents a SerialID that may be null.
+// Null is encoded as JSON null and as an empty string in text and protobuf string fields.
+type NullSerial struct {
+	Serial SerialID
+	Valid  bool // Valid is true if Serial is not NULL
+}
+
+// ParseNullSerial parses the string representation of a serial.
+// The empty string, the default of a protobuf string field, is parsed as null.
+func ParseNullSerial(in string) (NullSerial, error) {
+	var n NullSerial
+
+	err := n.UnmarshalText([]byte(in))
+
+	return n, err
+}
+
+// String returns the decimal representation of the serial, or the empty string if it is null.
+func (n NullSerial) String() string {
+	if !n.Valid {
+		return ""
+	}
+
+	return n.Serial.String()
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullSerial) Scan(value any) error {
+	var u NullUint64
+
+	err := u.Scan(value)
+
+	n.Serial, n.Valid = SerialID(u.Uint64), u.Valid
+
+	return err
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullSerial) Value() (driver.Value, error) {
+	return NullUint64{Uint64: uint64(n.Serial), Valid: n.Valid}.Value()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (n NullSerial) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (n *NullSerial) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullSerial{}
+
+		return nil
+	}
+
+	if err := n.Serial.UnmarshalText(text); err != nil {
+		*n = NullSerial{}
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullSerial) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Serial.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *NullSerial) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = NullSerial{}
+
+		return nil
+	}
+
+	return n.UnmarshalText(unquote(data))
+}
+
+// unquote strips the quotes of a JSON string, leaving other JSON values untouched.
+// Serials and dates only contain digits, dashes and letters, so no escape sequences need handling.
+func unquote(data []byte) []byte {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		return data[1 : len(data)-1]
+	}
+
+	return data
+}
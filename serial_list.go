@@ -0,0 +1,153 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidSerial is an element of a serial list that failed to parse.
+type InvalidSerial struct {
+	Index int
+	Input string
+	Err   error
+}
+
+// SerialListError reports every element of a serial list that failed to parse, not just the first.
+type SerialListError []InvalidSerial
+
+// Error implements the error interface.
+func (e SerialListError) Error() string {
+	elems := make([]string, len(e))
+
+	for i, invalid := range e {
+		elems[i] = fmt.Sprintf("%d ('%s')", invalid.Index, invalid.Input)
+	}
+
+	return fmt.Sprintf("sqlmap: failed to parse serials at index %s", strings.Join(elems, ", "))
+}
+
+// Unwrap returns the errors of the invalid elements, for use with errors.Is and errors.As.
+func (e SerialListError) Unwrap() []error {
+	errs := make([]error, len(e))
+
+	for i, invalid := range e {
+		errs[i] = invalid.Err
+	}
+
+	return errs
+}
+
+// SerialList parses lists of serials, such as the ids=1,2,3 query parameter of a list endpoint
+// or a repeated protobuf string field, for use in a WHERE id = ANY($1) query.
+// The zero value SerialList parses any number of serials and keeps duplicates.
+type SerialList struct {
+	// Max is the maximum number of elements in the list, before de-duplication. Zero means no limit.
+	Max int
+	// Deduplicate removes repeated serials, keeping the order of their first occurrence.
+	Deduplicate bool
+}
+
+// Split parses a comma separated list of serials.
+// White space around the elements is ignored and a blank string is an empty list.
+func (l SerialList) Split(in string) ([]uint64, error) {
+	return l.Parse(split(in))
+}
+
+// Parse parses each string of the slice as a serial.
+func (l SerialList) Parse(in []string) ([]uint64, error) {
+	return parseSerials(l, in, func(v uint64) (uint64, error) { return v, nil })
+}
+
+// SplitInt64 parses a comma separated list of serials into int64 values, the type of a BIGSERIAL column.
+// Serials above math.MaxInt64 are reported as invalid elements wrapping ErrOverflow.
+func (l SerialList) SplitInt64(in string) ([]int64, error) {
+	return l.ParseInt64(split(in))
+}
+
+// ParseInt64 parses each string of the slice as a serial into int64 values, the type of a BIGSERIAL column.
+// Serials above math.MaxInt64 are reported as invalid elements wrapping ErrOverflow.
+func (l SerialList) ParseInt64(in []string) ([]int64, error) {
+	return parseSerials(l, in, convertInteger[int64, uint64])
+}
+
+// SplitSerials parses a comma separated list of serials, see SerialList.Split.
+func SplitSerials(in string) ([]uint64, error) {
+	return SerialList{}.Split(in)
+}
+
+// ParseSerials parses each string of the slice as a serial, see SerialList.Parse.
+func ParseSerials(in []string) ([]uint64, error) {
+	return SerialList{}.Parse(in)
+}
+
+// parseSerials parses every element of the list and converts it to T.
+// All invalid elements are collected in a SerialListError.
+func parseSerials[T comparable](l SerialList, in []string, convert func(uint64) (T, error)) ([]T, error) {
+	if l.Max > 0 && len(in) > l.Max {
+		return nil, &ConversionError{
+			Source: "[]string",
+			Target: fmt.Sprintf("%T", []T{}),
+			Err:    fmt.Errorf("%w: got %d serials, at most %d allowed", ErrTooMany, len(in), l.Max),
+		}
+	}
+
+	var (
+		out     = make([]T, 0, len(in))
+		seen    = map[T]bool{}
+		invalid SerialListError
+	)
+
+	for i, s := range in {
+		v, err := ParseSerial(s)
+		if err != nil {
+			invalid = append(invalid, InvalidSerial{Index: i, Input: s, Err: err})
+
+			continue
+		}
+
+		t, err := convert(v)
+		if err != nil {
+			invalid = append(invalid, InvalidSerial{Index: i, Input: s, Err: err})
+
+			continue
+		}
+
+		if l.Deduplicate {
+			if seen[t] {
+				continue
+			}
+
+			seen[t] = true
+		}
+
+		out = append(out, t)
+	}
+
+	if len(invalid) > 0 {
+		return nil, invalid
+	}
+
+	return out, nil
+}
+
+// split splits the comma separated list, trimming white space around the elements.
+func split(in string) []string {
+	if strings.TrimSpace(in) == "" {
+		return nil
+	}
+
+	elems := strings.Split(in, ",")
+
+	for i, e := range elems {
+		elems[i] = strings.TrimSpace(e)
+	}
+
+	return elems
+}
@@ -0,0 +1,114 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSerialListSplit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		list     SerialList
+		input    string
+		expected []uint64
+	}{
+		{name: "should split comma separated serials", input: "1,2,3", expected: []uint64{1, 2, 3}},
+		{name: "should ignore white space", input: " 1 , 2,3 ", expected: []uint64{1, 2, 3}},
+		{name: "should split blank string into empty list", input: "  ", expected: []uint64{}},
+		{name: "should keep duplicates by default", input: "1,2,1", expected: []uint64{1, 2, 1}},
+		{name: "should remove duplicates", list: SerialList{Deduplicate: true}, input: "3,1,3,2,1", expected: []uint64{3, 1, 2}},
+		{name: "should allow max count", list: SerialList{Max: 3}, input: "1,2,3", expected: []uint64{1, 2, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.list.Split(tc.input)
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if len(result) != len(tc.expected) || !slices.Equal(result, tc.expected) {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSerialListErrors(t *testing.T) {
+	t.Run("should report every invalid element", func(t *testing.T) {
+		_, err := ParseSerials([]string{"1", "foo", "3", "-1", ""})
+
+		var listErr SerialListError
+		if !errors.As(err, &listErr) {
+			t.Fatalf("error should be a SerialListError, got: '%v'", err)
+		}
+
+		indexes := make([]int, len(listErr))
+		for i, invalid := range listErr {
+			indexes[i] = invalid.Index
+		}
+
+		if !slices.Equal(indexes, []int{1, 3, 4}) {
+			t.Fatalf("invalid indexes mismatch got '%v', expected: '%v'", indexes, []int{1, 3, 4})
+		}
+
+		expected := "sqlmap: failed to parse serials at index 1 ('foo'), 3 ('-1'), 4 ('')"
+		if err.Error() != expected {
+			t.Fatalf("error mismatch got '%v', expected: '%v'", err, expected)
+		}
+	})
+
+	t.Run("should reject lists above the max count", func(t *testing.T) {
+		_, err := (SerialList{Max: 2}).Split("1,2,3")
+		if !errors.Is(err, ErrTooMany) {
+			t.Fatalf("error should wrap ErrTooMany, got: '%v'", err)
+		}
+
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) || convErr.Source != "[]string" || convErr.Target != "[]uint64" {
+			t.Fatalf("error should be a ConversionError, got: '%+v'", convErr)
+		}
+
+		expected := "sqlmap: cannot convert []string to []uint64: too many elements: got 3 serials, at most 2 allowed"
+		if err.Error() != expected {
+			t.Fatalf("error mismatch got '%v', expected: '%v'", err, expected)
+		}
+	})
+
+	t.Run("should report serials overflowing int64", func(t *testing.T) {
+		_, err := SerialList{}.SplitInt64("1,18446744073709551615")
+		if !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
+
+func TestSerialListParseInt64(t *testing.T) {
+	result, err := SerialList{Deduplicate: true}.ParseInt64([]string{"42", "7", "42"})
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if !slices.Equal(result, []int64{42, 7}) {
+		t.Fatalf("result mismatch got '%v', expected: '%v'", result, []int64{42, 7})
+	}
+}
+
+func TestSplitSerials(t *testing.T) {
+	result, err := SplitSerials("1,2")
+	if err != nil {
+		t.Fatalf("function should not return error, got error: '%v'", err)
+	}
+
+	if !slices.Equal(result, []uint64{1, 2}) {
+		t.Fatalf("result mismatch got '%v', expected: '%v'", result, []uint64{1, 2})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestParseSerial(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedOutput uint64
+		expectedError  error
+	}{
+		{
+			name:           "should parse serialized integer",
+			input:          "42",
+			expectedOutput: 42,
+			expectedError:  nil,
+		},
+		{
+			name:           "should parse random string",
+			input:          "foobar",
+			expectedOutput: 0,
+			expectedError:  ErrInvalidFormat,
+		},
+		{
+			name:           "should fail to parse float",
+			input:          "1.1111",
+			expectedOutput: 0,
+			expectedError:  ErrInvalidFormat,
+		},
+		{
+			name:           "should fail to parse negative integer",
+			input:          "-1",
+			expectedOutput: 0,
+			expectedError:  ErrInvalidFormat,
+		},
+		{
+			name:           "should fail to parse integer above math.MaxUint64",
+			input:          "18446744073709551616",
+			expectedOutput: 0,
+			expectedError:  ErrOverflow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseSerial(tc.input)
+			if err != nil {
+				if tc.expectedError != nil {
+					// Assert the errors match.
+					if !errors.Is(err, tc.expectedError) {
+						t.Errorf("error mismatch; got '%v', expected: '%v'", err, tc.expectedError)
+					}
+
+					var numErr *strconv.NumError
+					if !errors.As(err, &numErr) || numErr.Num != tc.input {
+						t.Errorf("error should wrap the strconv.NumError, got: '%v'", err)
+					}
+
+					var convErr *ConversionError
+					if !errors.As(err, &convErr) || convErr.Source != "string" || convErr.Target != "uint64" || convErr.Value != tc.input {
+						t.Errorf("error should be a ConversionError, got: '%+v'", convErr)
+					}
+
+					return
+				}
+
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expectedOutput {
+				t.Fatalf("result: '%d' does not equal expected: '%d'", result, tc.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestSerialJSON(t *testing.T) {
+	type resource struct {
+		ID       SerialID   `json:"id"`
+		ParentID NullSerial `json:"parent_id"`
+	}
+
+	t.Run("should encode serials as quoted strings", func(t *testing.T) {
+		data, err := json.Marshal(resource{ID: math.MaxUint64, ParentID: NullSerial{Serial: 9007199254740993, Valid: true}})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		expected := `{"id":"18446744073709551615","parent_id":"9007199254740993"}`
+		if string(data) != expected {
+			t.Fatalf("result mismatch got '%s', expected: '%s'", data, expected)
+		}
+	})
+
+	t.Run("should encode null serials as null", func(t *testing.T) {
+		data, err := json.Marshal(resource{ID: 1})
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		expected := `{"id":"1","parent_id":null}`
+		if string(data) != expected {
+			t.Fatalf("result mismatch got '%s', expected: '%s'", data, expected)
+		}
+	})
+
+	t.Run("should decode quoted strings and numbers", func(t *testing.T) {
+		var r resource
+
+		if err := json.Unmarshal([]byte(`{"id":"18446744073709551615","parent_id":42}`), &r); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if r.ID != math.MaxUint64 || r.ParentID != (NullSerial{Serial: 42, Valid: true}) {
+			t.Fatalf("result mismatch got '%+v'", r)
+		}
+	})
+
+	t.Run("should decode null", func(t *testing.T) {
+		r := resource{ParentID: NullSerial{Serial: 42, Valid: true}}
+
+		if err := json.Unmarshal([]byte(`{"id":"1","parent_id":null}`), &r); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if r.ParentID.Valid {
+			t.Fatalf("result should be null, got: '%v'", r.ParentID)
+		}
+	})
+
+	t.Run("should leave the serial unchanged when decoding null", func(t *testing.T) {
+		r := resource{ID: 42}
+
+		if err := json.Unmarshal([]byte(`{"id":null}`), &r); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if r.ID != 42 {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", r.ID, 42)
+		}
+
+		s := SerialID(42)
+
+		if err := s.UnmarshalJSON([]byte("null")); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if s != 42 {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", s, 42)
+		}
+	})
+
+	t.Run("should fail to decode negative numbers", func(t *testing.T) {
+		var r resource
+
+		if err := json.Unmarshal([]byte(`{"id":"-1"}`), &r); err == nil {
+			t.Fatalf("function should return error")
+		}
+	})
+}
+
+func TestSerialScan(t *testing.T) {
+	t.Run("successfully scan and value round trip", func(t *testing.T) {
+		var s SerialID
+
+		if err := s.Scan(int64(42)); err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		v, err := s.Value()
+		if err != nil || v != int64(42) {
+			t.Fatalf("result mismatch got '%v' ('%v'), expected: '%d'", v, err, 42)
+		}
+	})
+
+	t.Run("should fail to scan NULL into SerialID", func(t *testing.T) {
+		var s SerialID
+
+		if err := s.Scan(nil); !errors.Is(err, ErrNull) {
+			t.Fatalf("error should wrap ErrNull, got: '%v'", err)
+		}
+	})
+
+	t.Run("successfully scan NULL into NullSerial", func(t *testing.T) {
+		n := NullSerial{Serial: 42, Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", n, err)
+		}
+
+		if v, err := n.Value(); v != nil || err != nil {
+			t.Fatalf("value should be nil without error, got: '%v', '%v'", v, err)
+		}
+	})
+}
+
+func TestParseNullSerial(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected NullSerial
+		fail     bool
+	}{
+		{name: "should parse empty proto string as null", input: "", expected: NullSerial{}},
+		{name: "should parse serial", input: "42", expected: NullSerial{Serial: 42, Valid: true}},
+		{name: "should fail to parse random string", input: "foobar", fail: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseNullSerial(tc.input)
+			if tc.fail {
+				if err == nil {
+					t.Fatalf("function should return error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", result, tc.expected)
+			}
+
+			if result.String() != tc.input {
+				t.Fatalf("round trip mismatch got '%s', expected: '%s'", result.String(), tc.input)
+			}
+		})
+	}
+}
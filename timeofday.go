@@ -0,0 +1,352 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a time of day stored in a TIME or TIMETZ column, with microsecond precision.
+// Like Postgres it allows 24:00:00, the end of a day, e.g. the closing time of a shop open until midnight.
+type TimeOfDay struct {
+	Hour        int
+	Minute      int
+	Second      int
+	Microsecond int
+	// Offset is the offset from UTC in seconds of a TIMETZ value, it is only set if HasOffset is.
+	Offset int
+	// HasOffset distinguishes a TIMETZ value at UTC from a TIME value without offset.
+	HasOffset bool
+}
+
+// TimeOfDayOf returns the time of day of the time in its location, without offset.
+// The time is truncated to whole microseconds.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Microsecond: t.Nanosecond() / 1000}
+}
+
+// TimeOfDayFromDuration returns the time of day the duration after midnight, without offset.
+// The duration is truncated to whole microseconds, durations outside of 0 to 24 hours return an error wrapping ErrOverflow.
+func TimeOfDayFromDuration(d time.Duration) (TimeOfDay, error) {
+	if d < 0 || d > 24*time.Hour {
+		return TimeOfDay{}, conversionError(d, "TimeOfDay", ErrOverflow, nil)
+	}
+
+	micros := int(d / time.Microsecond)
+
+	return TimeOfDay{
+		Hour:        micros / microsPerHour,
+		Minute:      micros / microsPerMinute % 60,
+		Second:      micros / microsPerSecond % 60,
+		Microsecond: micros % microsPerSecond,
+	}, nil
+}
+
+// ParseTimeOfDay parses a time of day with an optional UTC offset, e.g. "04:05:06.789-07:30".
+// It accepts the text output of Postgres TIME and TIMETZ columns, of MySQL TIME columns within a day,
+// and the "HH:MM", "HH:MM:SS" and "HH:MM:SS.SSS" formats of SQLite, as well as the Z suffix for UTC.
+// Invalid input returns a *ConversionError wrapping ErrInvalidFormat.
+func ParseTimeOfDay(in string) (TimeOfDay, error) {
+	t, ok := parseTimeOfDay(in)
+	if !ok {
+		return TimeOfDay{}, conversionError(in, "TimeOfDay", ErrInvalidFormat, nil)
+	}
+
+	return t, nil
+}
+
+// parseTimeOfDay parses a time of day, reporting whether the input is valid.
+func parseTimeOfDay(s string) (TimeOfDay, bool) {
+	var t TimeOfDay
+
+	clock, offset := s, ""
+
+	if i := strings.IndexAny(s, "+-Z"); i >= 0 {
+		clock, offset = s[:i], s[i:]
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return TimeOfDay{}, false
+	}
+
+	whole, frac, hasFrac := strings.Cut(parts[len(parts)-1], ".")
+	parts[len(parts)-1] = whole
+
+	fields := [3]*int{&t.Hour, &t.Minute, &t.Second}
+
+	for i, p := range parts {
+		n, ok := parseDigits(p, 2)
+		if !ok {
+			return TimeOfDay{}, false
+		}
+
+		*fields[i] = n
+	}
+
+	if hasFrac {
+		if len(parts) < 3 || frac == "" || len(frac) > 9 {
+			return TimeOfDay{}, false
+		}
+
+		// Digits beyond the microsecond precision of the column are truncated.
+		n, ok := parseDigits((frac + "00000")[:6], 6)
+		if !ok || strings.TrimLeft(frac, "0123456789") != "" {
+			return TimeOfDay{}, false
+		}
+
+		t.Microsecond = n
+	}
+
+	if offset != "" {
+		var ok bool
+
+		if t.Offset, ok = parseOffset(offset); !ok {
+			return TimeOfDay{}, false
+		}
+
+		t.HasOffset = true
+	}
+
+	return t, t.IsValid()
+}
+
+// parseOffset parses a UTC offset, e.g. "Z", "+02", "-07:30" or "+05:30:15", to seconds.
+func parseOffset(s string) (int, bool) {
+	if s == "Z" {
+		return 0, true
+	}
+
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+
+	parts := strings.Split(s[1:], ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+
+	// Offsets without colons, e.g. "+0530", are split into hours and minutes.
+	if len(parts) == 1 && len(parts[0]) == 4 {
+		parts = []string{parts[0][:2], parts[0][2:]}
+	}
+
+	offset := 0
+
+	for i, unit := range []int{60 * 60, 60, 1}[:len(parts)] {
+		n, ok := parseDigits(parts[i], 2)
+		if !ok || (i > 0 && n > 59) {
+			return 0, false
+		}
+
+		offset += n * unit
+	}
+
+	// Postgres limits offsets to 15:59:59.
+	if offset >= 16*60*60 {
+		return 0, false
+	}
+
+	return sign * offset, true
+}
+
+// parseDigits parses a non-negative decimal number of exactly n digits.
+func parseDigits(s string, n int) (int, bool) {
+	if len(s) != n {
+		return 0, false
+	}
+
+	v := 0
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+
+		v = v*10 + int(c-'0')
+	}
+
+	return v, true
+}
+
+// IsValid reports whether the time of day is within 00:00:00 and 24:00:00 and its offset within ±15:59:59.
+func (t TimeOfDay) IsValid() bool {
+	if t.Hour < 0 || t.Hour > 24 || t.Minute < 0 || t.Minute > 59 || t.Second < 0 || t.Second > 59 ||
+		t.Microsecond < 0 || t.Microsecond >= microsPerSecond {
+		return false
+	}
+
+	if t.Hour == 24 && (t.Minute != 0 || t.Second != 0 || t.Microsecond != 0) {
+		return false
+	}
+
+	return t.Offset > -16*60*60 && t.Offset < 16*60*60 && (t.HasOffset || t.Offset == 0)
+}
+
+// SinceMidnight returns the duration between midnight and the time of day, ignoring its offset.
+func (t TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Microsecond)*time.Microsecond
+}
+
+// On returns the time of day on the date.
+// The time is in the location of its offset, or in loc if it has none.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	if t.HasOffset {
+		loc = time.FixedZone("", t.Offset)
+	}
+
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Microsecond*1000, loc)
+}
+
+// String returns the time of day in the Postgres output format, e.g. "04:05:06.789-07:30".
+func (t TimeOfDay) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+
+	if t.Microsecond != 0 {
+		b.WriteString(strings.TrimRight(fmt.Sprintf(".%06d", t.Microsecond), "0"))
+	}
+
+	if t.HasOffset {
+		offset, sign := t.Offset, '+'
+		if offset < 0 {
+			offset, sign = -offset, '-'
+		}
+
+		fmt.Fprintf(&b, "%c%02d", sign, offset/3600)
+
+		if offset%3600 != 0 {
+			fmt.Fprintf(&b, ":%02d", offset/60%60)
+		}
+
+		if offset%60 != 0 {
+			fmt.Fprintf(&b, ":%02d", offset%60)
+		}
+	}
+
+	return b.String()
+}
+
+// Scan implements the sql.Scanner interface.
+// Use NullTimeOfDay to scan nullable columns.
+func (t *TimeOfDay) Scan(value any) error {
+	var n NullTimeOfDay
+
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+
+	if !n.Valid {
+		return &ConversionError{Source: "NULL", Target: "TimeOfDay", Err: fmt.Errorf("%w, use NullTimeOfDay", ErrNull)}
+	}
+
+	*t = n.TimeOfDay
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The time of day is written as a string, which TIME and TIMETZ columns accept in every supported database.
+// Invalid values, such as 25:00, return a *ConversionError wrapping ErrInvalidFormat.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	if !t.IsValid() {
+		return nil, conversionError(t, "TIME", ErrInvalidFormat, nil)
+	}
+
+	return t.String(), nil
+}
+
+// NullTimeOfDay represents a TimeOfDay that may be null.
+type NullTimeOfDay struct {
+	TimeOfDay TimeOfDay
+	Valid     bool // Valid is true if TimeOfDay is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the text formats described by ParseTimeOfDay, and a time.Time whose clock is taken in its location.
+func (n *NullTimeOfDay) Scan(value any) error {
+	var err error
+
+	switch v := value.(type) {
+	case nil:
+		*n = NullTimeOfDay{}
+
+		return nil
+	case time.Time:
+		n.TimeOfDay = TimeOfDayOf(v)
+	case []byte:
+		n.TimeOfDay, err = ParseTimeOfDay(string(v))
+	case string:
+		n.TimeOfDay, err = ParseTimeOfDay(v)
+	default:
+		err = conversionError(value, "NullTimeOfDay", ErrUnsupported, nil)
+	}
+
+	if err != nil {
+		*n = NullTimeOfDay{}
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTimeOfDay) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.TimeOfDay.Value()
+}
+
+// NullTimeOfDayFromDuration converts the duration since midnight, or duration pointer, to a NullTimeOfDay type.
+// A nil pointer is converted to null, durations outside of 0 to 24 hours return an error wrapping ErrOverflow.
+func NullTimeOfDayFromDuration[T time.Duration | *time.Duration](d T) (NullTimeOfDay, error) {
+	n := nullOf[time.Duration](d)
+	if !n.Valid {
+		return NullTimeOfDay{}, nil
+	}
+
+	t, err := TimeOfDayFromDuration(n.V)
+	if err != nil {
+		return NullTimeOfDay{}, err
+	}
+
+	return NullTimeOfDay{TimeOfDay: t, Valid: true}, nil
+}
+
+// UnwrapTimeOfDay unwraps the NullTimeOfDay to a TimeOfDay pointer.
+// If the value is null the function will return nil.
+func UnwrapTimeOfDay(n NullTimeOfDay) *TimeOfDay {
+	if !n.Valid {
+		return nil
+	}
+
+	return &n.TimeOfDay
+}
+
+// UnwrapSinceMidnight unwraps the NullTimeOfDay to a pointer to the duration since midnight, ignoring its offset.
+// If the value is null the function will return nil.
+func UnwrapSinceMidnight(n NullTimeOfDay) *time.Duration {
+	if !n.Valid {
+		return nil
+	}
+
+	d := n.TimeOfDay.SinceMidnight()
+
+	return &d
+}
@@ -0,0 +1,180 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected TimeOfDay
+		output   string
+		fail     bool
+	}{
+		{name: "should parse postgres time", input: "04:05:06", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6}},
+		{name: "should parse microseconds", input: "04:05:06.000789", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Microsecond: 789}},
+		{name: "should parse end of day", input: "24:00:00", expected: TimeOfDay{Hour: 24}},
+		{name: "should parse postgres timetz", input: "04:05:06.5+02", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Microsecond: 500000, Offset: 2 * 60 * 60, HasOffset: true}},
+		{name: "should parse negative offset with minutes", input: "04:05:06-07:30", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Offset: -(7*60 + 30) * 60, HasOffset: true}},
+		{name: "should parse offset with seconds", input: "04:05:06+05:30:15", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Offset: (5*60+30)*60 + 15, HasOffset: true}},
+		{name: "should parse UTC offset", input: "04:05:06+00", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, HasOffset: true}},
+		{name: "should parse Z suffix", input: "04:05:06Z", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, HasOffset: true}, output: "04:05:06+00"},
+		{name: "should parse offset without colon", input: "04:05:06+0530", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Offset: 330 * 60, HasOffset: true}, output: "04:05:06+05:30"},
+		{name: "should parse sqlite minutes", input: "04:05", expected: TimeOfDay{Hour: 4, Minute: 5}, output: "04:05:00"},
+		{name: "should parse sqlite milliseconds", input: "04:05:06.789", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Microsecond: 789000}},
+		{name: "should truncate nanoseconds", input: "04:05:06.123456789", expected: TimeOfDay{Hour: 4, Minute: 5, Second: 6, Microsecond: 123456}, output: "04:05:06.123456"},
+		{name: "should fail to parse past end of day", input: "24:00:01", fail: true},
+		{name: "should fail to parse mysql duration", input: "838:59:59", fail: true},
+		{name: "should fail to parse negative mysql time", input: "-01:00:00", fail: true},
+		{name: "should fail to parse invalid minutes", input: "04:60:00", fail: true},
+		{name: "should fail to parse offset beyond 15:59:59", input: "04:05:06+16", fail: true},
+		{name: "should fail to parse random string", input: "foobar", fail: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseTimeOfDay(tc.input)
+			if tc.fail {
+				if !errors.Is(err, ErrInvalidFormat) {
+					t.Fatalf("error should wrap ErrInvalidFormat, got: '%v'", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%+v', expected: '%+v'", result, tc.expected)
+			}
+
+			output := tc.output
+			if output == "" {
+				output = tc.input
+			}
+
+			if result.String() != output {
+				t.Fatalf("string mismatch got '%s', expected: '%s'", result, output)
+			}
+		})
+	}
+}
+
+func TestTimeOfDayDuration(t *testing.T) {
+	t.Run("should round trip duration since midnight", func(t *testing.T) {
+		d := 13*time.Hour + 30*time.Minute + 1500*time.Microsecond
+
+		result, err := TimeOfDayFromDuration(d)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result.SinceMidnight() != d {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result.SinceMidnight(), d)
+		}
+	})
+
+	t.Run("should reject durations outside a day", func(t *testing.T) {
+		for _, d := range []time.Duration{-time.Second, 24*time.Hour + time.Microsecond} {
+			if _, err := TimeOfDayFromDuration(d); !errors.Is(err, ErrOverflow) {
+				t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+			}
+		}
+	})
+
+	t.Run("successfully convert nullable durations", func(t *testing.T) {
+		n, err := NullTimeOfDayFromDuration(time.Hour)
+		if err != nil || !n.Valid || n.TimeOfDay != (TimeOfDay{Hour: 1}) {
+			t.Fatalf("result mismatch got '%+v' (%v)", n, err)
+		}
+
+		if result := UnwrapSinceMidnight(n); result == nil || *result != time.Hour {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, time.Hour)
+		}
+
+		if n, err := NullTimeOfDayFromDuration[*time.Duration](nil); err != nil || n.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", n, err)
+		}
+
+		if result := UnwrapSinceMidnight(NullTimeOfDay{}); result != nil {
+			t.Fatalf("result should be nil, got: '%v'", result)
+		}
+	})
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	d := Date{Year: 2024, Month: time.January, Day: 1}
+
+	withOffset := TimeOfDay{Hour: 9, HasOffset: true, Offset: -5 * 60 * 60}
+	if result := withOffset.On(d, time.UTC); !result.Equal(time.Date(2024, time.January, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Fatalf("result mismatch got '%v'", result)
+	}
+
+	if result := (TimeOfDay{Hour: 9}).On(d, time.UTC); !result.Equal(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("result mismatch got '%v'", result)
+	}
+}
+
+func TestNullTimeOfDayScan(t *testing.T) {
+	t.Run("successfully scan text output", func(t *testing.T) {
+		var n NullTimeOfDay
+
+		if err := n.Scan([]byte("09:00:00+01")); err != nil || !n.Valid || n.TimeOfDay != (TimeOfDay{Hour: 9, Offset: 3600, HasOffset: true}) {
+			t.Fatalf("result mismatch got '%+v' (%v)", n, err)
+		}
+	})
+
+	t.Run("successfully scan time.Time", func(t *testing.T) {
+		var n NullTimeOfDay
+
+		if err := n.Scan(time.Date(0, time.January, 1, 9, 30, 0, 1500, time.UTC)); err != nil || n.TimeOfDay != (TimeOfDay{Hour: 9, Minute: 30, Microsecond: 1}) {
+			t.Fatalf("result mismatch got '%+v' (%v)", n, err)
+		}
+	})
+
+	t.Run("successfully scan NULL", func(t *testing.T) {
+		n := NullTimeOfDay{Valid: true}
+
+		if err := n.Scan(nil); err != nil || n.Valid {
+			t.Fatalf("result should be null, got: '%+v' (%v)", n, err)
+		}
+	})
+
+	t.Run("should fail to scan NULL into TimeOfDay", func(t *testing.T) {
+		var tod TimeOfDay
+
+		if err := tod.Scan(nil); !errors.Is(err, ErrNull) {
+			t.Fatalf("error should wrap ErrNull, got: '%v'", err)
+		}
+	})
+
+	t.Run("successfully write value", func(t *testing.T) {
+		if result, err := (NullTimeOfDay{TimeOfDay: TimeOfDay{Hour: 9}, Valid: true}).Value(); err != nil || result != "09:00:00" {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '09:00:00'", result, err)
+		}
+
+		if result, err := (NullTimeOfDay{}).Value(); err != nil || result != nil {
+			t.Fatalf("result should be nil, got: '%v' (%v)", result, err)
+		}
+	})
+
+	t.Run("should fail to write invalid value", func(t *testing.T) {
+		for _, invalid := range []TimeOfDay{{Hour: 25}, {Hour: 24, Minute: 1}, {Hour: 9, Offset: 3600}} {
+			if _, err := (NullTimeOfDay{TimeOfDay: invalid, Valid: true}).Value(); !errors.Is(err, ErrInvalidFormat) {
+				t.Fatalf("error should wrap ErrInvalidFormat for '%+v', got: '%v'", invalid, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"strconv"
+)
+
+// NullUint64 represents a uint64 that may be null, there is no sql.NullUint64 in the standard library.
+// NullUint64 implements the sql.Scanner interface so it can be used as a scan destination,
+// and the driver.Valuer interface so it can be used as a query argument.
+//
+// Postgres has no unsigned integer type, so values above math.MaxInt64 are written as a decimal string,
+// which is accepted by NUMERIC(20) columns.
+type NullUint64 struct {
+	Uint64 uint64
+	Valid  bool // Valid is true if Uint64 is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts integers, including the uint64 returned for BIGINT UNSIGNED columns by MySQL drivers,
+// as well as the textual output of NUMERIC columns.
+func (n *NullUint64) Scan(value any) error {
+	var err error
+
+	switch v := value.(type) {
+	case nil:
+		n.Uint64, n.Valid = 0, false
+
+		return nil
+	case int64:
+		n.Uint64, err = convertInteger[uint64](v)
+	case uint64:
+		n.Uint64 = v
+	case []byte:
+		n.Uint64, err = parseUint(string(v))
+	case string:
+		n.Uint64, err = parseUint(v)
+	default:
+		err = conversionError(value, "NullUint64", ErrUnsupported, nil)
+	}
+
+	if err != nil {
+		n.Uint64, n.Valid = 0, false
+
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// Values above math.MaxInt64 are returned as a decimal string.
+func (n NullUint64) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if n.Uint64 > math.MaxInt64 {
+		return strconv.FormatUint(n.Uint64, 10), nil
+	}
+
+	return int64(n.Uint64), nil
+}
+
+// NullUint64From converts the native go uint64 type to a NullUint64 type.
+// It is the infallible counterpart of NullUint64Of, named apart from the NullUint64 type.
+func NullUint64From[T uint64 | *uint64](i T) NullUint64 {
+	return ToNullUint64(nullOf[uint64](i))
+}
+
+// NullUint64Of converts any go integer type to a NullUint64 type.
+// Negative values return an error wrapping ErrOverflow.
+// The uint64 returned by ParseSerial can be converted without error.
+func NullUint64Of[T Integer](i T) (NullUint64, error) {
+	return NullUint64OfPtr(&i)
+}
+
+// NullUint64OfPtr converts a pointer to any go integer type to a NullUint64 type.
+// Negative values return an error wrapping ErrOverflow.
+func NullUint64OfPtr[T Integer](i *T) (NullUint64, error) {
+	n, err := convertIntegerPtr[uint64](i)
+
+	return ToNullUint64(n), err
+}
+
+// FromNullUint64 converts a NullUint64 to a sql.Null[uint64] type.
+func FromNullUint64(n NullUint64) sql.Null[uint64] {
+	return sql.Null[uint64]{V: n.Uint64, Valid: n.Valid}
+}
+
+// ToNullUint64 converts a sql.Null[uint64] to a NullUint64 type.
+func ToNullUint64(n sql.Null[uint64]) NullUint64 {
+	return NullUint64{Uint64: n.V, Valid: n.Valid}
+}
+
+// UnwrapUint64 unwraps the NullUint64 to a uint64 pointer.
+func UnwrapUint64(i NullUint64) *uint64 {
+	return Unwrap(FromNullUint64(i))
+}
+
+// UnwrapUint64Or unwraps the NullUint64 to a uint64.
+// If the value is null the function will return the fallback value.
+func UnwrapUint64Or(i NullUint64, fallback uint64) uint64 {
+	return UnwrapOr(FromNullUint64(i), fallback)
+}
+
+// UnwrapUint64OrZero unwraps the NullUint64 to a uint64.
+// If the value is null the function will return the zero value.
+func UnwrapUint64OrZero(i NullUint64) uint64 {
+	return UnwrapOrZero(FromNullUint64(i))
+}
@@ -0,0 +1,156 @@
+// Copyright 2023, 2024 Justin Simmons.
+//
+// This file is part of sqlmap.
+// sqlmap is free software: you can redistribute it and/or modify it under the terms of the GNU Lesser General Public License as published by the Free Software Foundation, either version 3 of the License, or any later version.
+// sqlmap is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU Lesser General Public License for more details.
+// You should have received a copy of the GNU Lesser General Public License along with sqlmap. If not, see <https://www.gnu.org/licenses/>.
+
+package sqlmap
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNullUint64Scan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    any
+		expected NullUint64
+		fail     bool
+	}{
+		{name: "should scan null", input: nil, expected: NullUint64{}},
+		{name: "should scan int64", input: int64(42), expected: NullUint64{Uint64: 42, Valid: true}},
+		{name: "should scan BIGINT UNSIGNED uint64", input: uint64(math.MaxUint64), expected: NullUint64{Uint64: math.MaxUint64, Valid: true}},
+		{name: "should scan NUMERIC text", input: []byte("18446744073709551615"), expected: NullUint64{Uint64: math.MaxUint64, Valid: true}},
+		{name: "should scan string", input: "42", expected: NullUint64{Uint64: 42, Valid: true}},
+		{name: "should fail to scan negative int64", input: int64(-1), fail: true},
+		{name: "should fail to scan fractional NUMERIC text", input: []byte("1.5"), fail: true},
+		{name: "should fail to scan unsupported type", input: 1.5, fail: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NullUint64{Uint64: 7, Valid: true}
+
+			err := result.Scan(tc.input)
+			if tc.fail {
+				if err == nil {
+					t.Fatalf("function should return error")
+				}
+
+				if result.Valid {
+					t.Fatalf("result should not be valid on error, got: '%v'", result)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNullUint64Value(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    NullUint64
+		expected driver.Value
+	}{
+		{name: "should write null", input: NullUint64{Uint64: 42}, expected: nil},
+		{name: "should write int64", input: NullUint64{Uint64: 42, Valid: true}, expected: int64(42)},
+		{name: "should write math.MaxInt64 as int64", input: NullUint64{Uint64: math.MaxInt64, Valid: true}, expected: int64(math.MaxInt64)},
+		{name: "should write values above math.MaxInt64 as string", input: NullUint64{Uint64: math.MaxUint64, Valid: true}, expected: "18446744073709551615"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.input.Value()
+			if err != nil {
+				t.Fatalf("function should not return error, got error: '%v'", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNullUint64From(t *testing.T) {
+	t.Run("successfully handle non-pointer uint64 values", func(t *testing.T) {
+		if result := NullUint64From(uint64(math.MaxUint64)); result != (NullUint64{Uint64: math.MaxUint64, Valid: true}) {
+			t.Fatalf("result mismatch got '%v', expected: '%d'", result, uint64(math.MaxUint64))
+		}
+	})
+
+	t.Run("successfully handle zero uint64 pointers", func(t *testing.T) {
+		if result := NullUint64From(ptr[uint64](0)); result != (NullUint64{Valid: true}) {
+			t.Fatalf("result should be valid zero, got: '%v'", result)
+		}
+	})
+
+	t.Run("successfully handle null uint64 pointers", func(t *testing.T) {
+		var v *uint64
+
+		if result := NullUint64From(v); result.Valid {
+			t.Fatalf("result should be null, got: '%v'", result)
+		}
+	})
+}
+
+func TestNullUint64Of(t *testing.T) {
+	t.Run("successfully convert ParseSerial output", func(t *testing.T) {
+		id, _ := ParseSerial("18446744073709551615")
+
+		result, err := NullUint64Of(id)
+		if err != nil {
+			t.Fatalf("function should not return error, got error: '%v'", err)
+		}
+
+		if result != (NullUint64{Uint64: math.MaxUint64, Valid: true}) {
+			t.Fatalf("result mismatch got '%v', expected: '%d'", result, uint64(math.MaxUint64))
+		}
+	})
+
+	t.Run("should fail to convert negative values", func(t *testing.T) {
+		if _, err := NullUint64Of(-1); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+
+	t.Run("successfully handle null pointers", func(t *testing.T) {
+		var v *int
+
+		result, err := NullUint64OfPtr(v)
+		if err != nil || result.Valid {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", result, err)
+		}
+	})
+}
+
+func TestUnwrapUint64(t *testing.T) {
+	if result := UnwrapUint64(NullUint64{Uint64: 42, Valid: true}); result == nil || *result != 42 {
+		t.Fatalf("result mismatch got '%v', expected: '%d'", result, 42)
+	}
+
+	if result := UnwrapUint64(NullUint64{Uint64: 42}); result != nil {
+		t.Fatalf("result should be null, got: '%d'", *result)
+	}
+
+	if result := UnwrapUint64Or(NullUint64{Uint64: 42}, 7); result != 7 {
+		t.Fatalf("result mismatch got '%d', expected: '%d'", result, 7)
+	}
+
+	if result := UnwrapUint64OrZero(NullUint64{Uint64: 42}); result != 0 {
+		t.Fatalf("result should be zero value, got: '%d'", result)
+	}
+}
@@ -9,87 +9,209 @@ package sqlmap
 
 import (
 	"database/sql"
+	"math"
 	"time"
 )
 
-// UnwrapString unwraps the sql null string to a string pointer.
-func UnwrapString(s sql.NullString) *string {
-	if !s.Valid {
+// Unwrap unwraps the generic sql.Null[T] to a pointer of type T.
+func Unwrap[T any](n sql.Null[T]) *T {
+	if !n.Valid {
 		return nil
 	}
 
-	return &s.String
+	return &n.V
+}
+
+// UnwrapOr unwraps the generic sql.Null[T] to a value of type T.
+// If the value is null the function will return the fallback value.
+func UnwrapOr[T any](n sql.Null[T], fallback T) T {
+	if !n.Valid {
+		return fallback
+	}
+
+	return n.V
+}
+
+// UnwrapOrZero unwraps the generic sql.Null[T] to a value of type T.
+// If the value is null the function will return the zero value of T.
+func UnwrapOrZero[T any](n sql.Null[T]) T {
+	var zero T
+
+	return UnwrapOr(n, zero)
+}
+
+// UnwrapString unwraps the sql null string to a string pointer.
+func UnwrapString(s sql.NullString) *string {
+	return Unwrap(FromNullString(s))
 }
 
 // UnwrapTime unwraps the sql null time to a time.Time struct.
 // If the value is null the function will return an empty time.Time struct.
 func UnwrapTime(t sql.NullTime) time.Time {
-	if !t.Valid {
-		return time.Time{}
-	}
-
-	return t.Time
+	return UnwrapTimeOrZero(t)
 }
 
 // UnwrapTimePtr unwraps the sql null time to a time.Time pointer.
 func UnwrapTimePtr(t sql.NullTime) *time.Time {
-	if !t.Valid {
-		return nil
-	}
-
-	return &t.Time
+	return Unwrap(FromNullTime(t))
 }
 
 // UnwrapInt64 unwraps the sql.NullInt64 to a int64 pointer.
 func UnwrapInt64(i sql.NullInt64) *int64 {
-	if !i.Valid {
-		return nil
-	}
-
-	return &i.Int64
+	return Unwrap(FromNullInt64(i))
 }
 
 // UnwrapInt32 unwraps the sql.NullInt32 to a int32 pointer.
 func UnwrapInt32(i sql.NullInt32) *int32 {
-	if !i.Valid {
-		return nil
-	}
-
-	return &i.Int32
+	return Unwrap(FromNullInt32(i))
 }
 
 // UnwrapInt16 unwraps the sql.NullInt16 to a int16 pointer.
 func UnwrapInt16(i sql.NullInt16) *int16 {
-	if !i.Valid {
-		return nil
-	}
-
-	return &i.Int16
+	return Unwrap(FromNullInt16(i))
 }
 
 // UnwrapByte unwraps the sql.NullByte to a byte pointer.
 func UnwrapByte(b sql.NullByte) *byte {
-	if !b.Valid {
-		return nil
-	}
-
-	return &b.Byte
+	return Unwrap(FromNullByte(b))
 }
 
 // UnwrapFloat64 unwraps the sql.NullFloat64 to a float64 pointer.
 func UnwrapFloat64(f sql.NullFloat64) *float64 {
+	return Unwrap(FromNullFloat64(f))
+}
+
+// UnwrapFloat32 unwraps the sql.Null[float32] to a float32 pointer.
+func UnwrapFloat32(f sql.Null[float32]) *float32 {
+	return Unwrap(f)
+}
+
+// UnwrapReal unwraps the sql.NullFloat64 of a REAL column to a float32 pointer.
+// If the value is null the function will return nil.
+// Finite values outside the range of a float32 return an error wrapping ErrOverflow, smaller values are rounded.
+func UnwrapReal(f sql.NullFloat64) (*float32, error) {
 	if !f.Valid {
-		return nil
+		return nil, nil
+	}
+
+	if math.Abs(f.Float64) > math.MaxFloat32 && !math.IsInf(f.Float64, 0) {
+		return nil, conversionError(f.Float64, "float32", ErrOverflow, nil)
 	}
 
-	return &f.Float64
+	v := float32(f.Float64)
+
+	return &v, nil
 }
 
 // UnwrapBoolean unwraps the sql.NullBool to a boolean pointer.
 func UnwrapBoolean(b sql.NullBool) *bool {
-	if !b.Valid {
-		return nil
-	}
+	return Unwrap(FromNullBool(b))
+}
+
+// UnwrapStringOr unwraps the sql.NullString to a string.
+// If the value is null the function will return the fallback value.
+func UnwrapStringOr(s sql.NullString, fallback string) string {
+	return UnwrapOr(FromNullString(s), fallback)
+}
+
+// UnwrapStringOrZero unwraps the sql.NullString to a string.
+// If the value is null the function will return the zero value.
+func UnwrapStringOrZero(s sql.NullString) string {
+	return UnwrapOrZero(FromNullString(s))
+}
+
+// UnwrapTimeOr unwraps the sql.NullTime to a time.Time struct.
+// If the value is null the function will return the fallback value.
+func UnwrapTimeOr(t sql.NullTime, fallback time.Time) time.Time {
+	return UnwrapOr(FromNullTime(t), fallback)
+}
+
+// UnwrapTimeOrZero unwraps the sql.NullTime to a time.Time struct.
+// If the value is null the function will return the zero value.
+func UnwrapTimeOrZero(t sql.NullTime) time.Time {
+	return UnwrapOrZero(FromNullTime(t))
+}
+
+// UnwrapInt64Or unwraps the sql.NullInt64 to a int64.
+// If the value is null the function will return the fallback value.
+func UnwrapInt64Or(i sql.NullInt64, fallback int64) int64 {
+	return UnwrapOr(FromNullInt64(i), fallback)
+}
+
+// UnwrapInt64OrZero unwraps the sql.NullInt64 to a int64.
+// If the value is null the function will return the zero value.
+func UnwrapInt64OrZero(i sql.NullInt64) int64 {
+	return UnwrapOrZero(FromNullInt64(i))
+}
+
+// UnwrapInt32Or unwraps the sql.NullInt32 to a int32.
+// If the value is null the function will return the fallback value.
+func UnwrapInt32Or(i sql.NullInt32, fallback int32) int32 {
+	return UnwrapOr(FromNullInt32(i), fallback)
+}
+
+// UnwrapInt32OrZero unwraps the sql.NullInt32 to a int32.
+// If the value is null the function will return the zero value.
+func UnwrapInt32OrZero(i sql.NullInt32) int32 {
+	return UnwrapOrZero(FromNullInt32(i))
+}
+
+// UnwrapInt16Or unwraps the sql.NullInt16 to a int16.
+// If the value is null the function will return the fallback value.
+func UnwrapInt16Or(i sql.NullInt16, fallback int16) int16 {
+	return UnwrapOr(FromNullInt16(i), fallback)
+}
+
+// UnwrapInt16OrZero unwraps the sql.NullInt16 to a int16.
+// If the value is null the function will return the zero value.
+func UnwrapInt16OrZero(i sql.NullInt16) int16 {
+	return UnwrapOrZero(FromNullInt16(i))
+}
+
+// UnwrapByteOr unwraps the sql.NullByte to a byte.
+// If the value is null the function will return the fallback value.
+func UnwrapByteOr(b sql.NullByte, fallback byte) byte {
+	return UnwrapOr(FromNullByte(b), fallback)
+}
+
+// UnwrapByteOrZero unwraps the sql.NullByte to a byte.
+// If the value is null the function will return the zero value.
+func UnwrapByteOrZero(b sql.NullByte) byte {
+	return UnwrapOrZero(FromNullByte(b))
+}
+
+// UnwrapFloat64Or unwraps the sql.NullFloat64 to a float64.
+// If the value is null the function will return the fallback value.
+func UnwrapFloat64Or(f sql.NullFloat64, fallback float64) float64 {
+	return UnwrapOr(FromNullFloat64(f), fallback)
+}
+
+// UnwrapFloat64OrZero unwraps the sql.NullFloat64 to a float64.
+// If the value is null the function will return the zero value.
+func UnwrapFloat64OrZero(f sql.NullFloat64) float64 {
+	return UnwrapOrZero(FromNullFloat64(f))
+}
+
+// UnwrapFloat32Or unwraps the sql.Null[float32] to a float32.
+// If the value is null the function will return the fallback value.
+func UnwrapFloat32Or(f sql.Null[float32], fallback float32) float32 {
+	return UnwrapOr(f, fallback)
+}
+
+// UnwrapFloat32OrZero unwraps the sql.Null[float32] to a float32.
+// If the value is null the function will return the zero value.
+func UnwrapFloat32OrZero(f sql.Null[float32]) float32 {
+	return UnwrapOrZero(f)
+}
+
+// UnwrapBooleanOr unwraps the sql.NullBool to a boolean.
+// If the value is null the function will return the fallback value.
+func UnwrapBooleanOr(b sql.NullBool, fallback bool) bool {
+	return UnwrapOr(FromNullBool(b), fallback)
+}
 
-	return &b.Bool
+// UnwrapBooleanOrZero unwraps the sql.NullBool to a boolean.
+// If the value is null the function will return the zero value.
+func UnwrapBooleanOrZero(b sql.NullBool) bool {
+	return UnwrapOrZero(FromNullBool(b))
 }
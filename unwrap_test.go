@@ -9,6 +9,8 @@ package sqlmap
 
 import (
 	"database/sql"
+	"errors"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -422,6 +424,43 @@ func TestUnwrapFloat64(t *testing.T) {
 	}
 }
 
+func TestUnwrapFloat32(t *testing.T) {
+	if result := UnwrapFloat32(sql.Null[float32]{V: 1.5, Valid: true}); result == nil || *result != 1.5 {
+		t.Fatalf("result mismatch got '%v', expected: '%v'", result, 1.5)
+	}
+
+	if result := UnwrapFloat32Or(sql.Null[float32]{V: 1.5}, 7); result != 7 {
+		t.Fatalf("result mismatch got '%v', expected: '%v'", result, 7)
+	}
+
+	if result := UnwrapFloat32OrZero(sql.Null[float32]{V: 1.5}); result != 0 {
+		t.Fatalf("result should be zero value, got: '%v'", result)
+	}
+}
+
+func TestUnwrapReal(t *testing.T) {
+	t.Run("successfully round trip REAL value", func(t *testing.T) {
+		v := float32(0.1)
+
+		result, err := UnwrapReal(NullReal(v))
+		if err != nil || result == nil || *result != v {
+			t.Fatalf("result mismatch got '%v' (%v), expected: '%v'", result, err, v)
+		}
+	})
+
+	t.Run("successfully unwrap null", func(t *testing.T) {
+		if result, err := UnwrapReal(sql.NullFloat64{Float64: 1}); result != nil || err != nil {
+			t.Fatalf("result should be null without error, got: '%v', '%v'", result, err)
+		}
+	})
+
+	t.Run("should fail to unwrap values outside float32 range", func(t *testing.T) {
+		if _, err := UnwrapReal(sql.NullFloat64{Float64: math.MaxFloat64, Valid: true}); !errors.Is(err, ErrOverflow) {
+			t.Fatalf("error should wrap ErrOverflow, got: '%v'", err)
+		}
+	})
+}
+
 func TestUnwrapBoolean(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -472,3 +511,147 @@ func TestUnwrapBoolean(t *testing.T) {
 		})
 	}
 }
+
+func TestUnwrap(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input sql.Null[float32]
+	}{
+		{
+			name: "should successfully unwrap sql.Null[float32] with valid value",
+			input: sql.Null[float32]{
+				V:     1.5,
+				Valid: true,
+			},
+		},
+		{
+			name: "should successfully unwrap sql.Null[float32] with null value",
+			input: sql.Null[float32]{
+				V:     0,
+				Valid: false,
+			},
+		},
+		{
+			name: "should successfully unwrap invalid sql.Null[float32] with value",
+			input: sql.Null[float32]{
+				V:     1.5,
+				Valid: false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Unwrap(tc.input)
+
+			if !tc.input.Valid {
+				if result != nil {
+					t.Fatalf("result should be null, got: '%v'", *result)
+				}
+
+				return
+			}
+
+			if result == nil {
+				t.Fatalf("result should not be null, expected: '%v'", tc.input.V)
+			}
+
+			if *result != tc.input.V {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", *result, tc.input.V)
+			}
+		})
+	}
+}
+
+func TestUnwrapOr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    sql.Null[int64]
+		fallback int64
+		expected int64
+	}{
+		{
+			name:     "should return value of valid sql.Null[int64]",
+			input:    sql.Null[int64]{V: 42, Valid: true},
+			fallback: 7,
+			expected: 42,
+		},
+		{
+			name:     "should return fallback of null sql.Null[int64]",
+			input:    sql.Null[int64]{V: 0, Valid: false},
+			fallback: 7,
+			expected: 7,
+		},
+		{
+			name:     "should return fallback of invalid sql.Null[int64] with value",
+			input:    sql.Null[int64]{V: 42, Valid: false},
+			fallback: 7,
+			expected: 7,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := UnwrapOr(tc.input, tc.fallback); result != tc.expected {
+				t.Fatalf("result mismatch got '%d', expected: '%d'", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUnwrapOrZero(t *testing.T) {
+	t.Run("should return value of valid sql.Null[string]", func(t *testing.T) {
+		if result := UnwrapOrZero(sql.Null[string]{V: "foo", Valid: true}); result != "foo" {
+			t.Fatalf("result mismatch got '%s', expected: '%s'", result, "foo")
+		}
+	})
+
+	t.Run("should return zero value of invalid sql.Null[string] with value", func(t *testing.T) {
+		if result := UnwrapOrZero(sql.Null[string]{V: "foo", Valid: false}); result != "" {
+			t.Fatalf("result should be zero value, got: '%s'", result)
+		}
+	})
+}
+
+func TestUnwrapTypedOr(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		result   any
+		expected any
+	}{
+		{name: "UnwrapStringOr valid", result: UnwrapStringOr(sql.NullString{String: "foo", Valid: true}, "bar"), expected: "foo"},
+		{name: "UnwrapStringOr null", result: UnwrapStringOr(sql.NullString{String: "foo"}, "bar"), expected: "bar"},
+		{name: "UnwrapStringOrZero null", result: UnwrapStringOrZero(sql.NullString{String: "foo"}), expected: ""},
+		{name: "UnwrapTimeOr valid", result: UnwrapTimeOr(sql.NullTime{Time: now, Valid: true}, time.Time{}), expected: now},
+		{name: "UnwrapTimeOr null", result: UnwrapTimeOr(sql.NullTime{Time: now}, time.Unix(0, 0)), expected: time.Unix(0, 0)},
+		{name: "UnwrapTimeOrZero null", result: UnwrapTimeOrZero(sql.NullTime{Time: now}), expected: time.Time{}},
+		{name: "UnwrapInt64Or valid", result: UnwrapInt64Or(sql.NullInt64{Int64: 42, Valid: true}, 7), expected: int64(42)},
+		{name: "UnwrapInt64Or null", result: UnwrapInt64Or(sql.NullInt64{Int64: 42}, 7), expected: int64(7)},
+		{name: "UnwrapInt64OrZero null", result: UnwrapInt64OrZero(sql.NullInt64{Int64: 42}), expected: int64(0)},
+		{name: "UnwrapInt32Or valid", result: UnwrapInt32Or(sql.NullInt32{Int32: 42, Valid: true}, 7), expected: int32(42)},
+		{name: "UnwrapInt32Or null", result: UnwrapInt32Or(sql.NullInt32{Int32: 42}, 7), expected: int32(7)},
+		{name: "UnwrapInt32OrZero null", result: UnwrapInt32OrZero(sql.NullInt32{Int32: 42}), expected: int32(0)},
+		{name: "UnwrapInt16Or valid", result: UnwrapInt16Or(sql.NullInt16{Int16: 42, Valid: true}, 7), expected: int16(42)},
+		{name: "UnwrapInt16Or null", result: UnwrapInt16Or(sql.NullInt16{Int16: 42}, 7), expected: int16(7)},
+		{name: "UnwrapInt16OrZero null", result: UnwrapInt16OrZero(sql.NullInt16{Int16: 42}), expected: int16(0)},
+		{name: "UnwrapByteOr valid", result: UnwrapByteOr(sql.NullByte{Byte: 1, Valid: true}, 2), expected: byte(1)},
+		{name: "UnwrapByteOr null", result: UnwrapByteOr(sql.NullByte{Byte: 1}, 2), expected: byte(2)},
+		{name: "UnwrapByteOrZero null", result: UnwrapByteOrZero(sql.NullByte{Byte: 1}), expected: byte(0)},
+		{name: "UnwrapFloat64Or valid", result: UnwrapFloat64Or(sql.NullFloat64{Float64: 1.5, Valid: true}, 2.5), expected: 1.5},
+		{name: "UnwrapFloat64Or null", result: UnwrapFloat64Or(sql.NullFloat64{Float64: 1.5}, 2.5), expected: 2.5},
+		{name: "UnwrapFloat64OrZero null", result: UnwrapFloat64OrZero(sql.NullFloat64{Float64: 1.5}), expected: 0.0},
+		{name: "UnwrapBooleanOr valid", result: UnwrapBooleanOr(sql.NullBool{Bool: false, Valid: true}, true), expected: false},
+		{name: "UnwrapBooleanOr null", result: UnwrapBooleanOr(sql.NullBool{Bool: false}, true), expected: true},
+		{name: "UnwrapBooleanOrZero null", result: UnwrapBooleanOrZero(sql.NullBool{Bool: true}), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.result != tc.expected {
+				t.Fatalf("result mismatch got '%v', expected: '%v'", tc.result, tc.expected)
+			}
+		})
+	}
+}
@@ -13,38 +13,29 @@ import (
 
 // NullUUID converts a google UUID to a uuid.NullUUID type.
 func NullUUID[T uuid.UUID | *uuid.UUID](id T) uuid.NullUUID {
-	nUUID := uuid.NullUUID{}
-
-	switch v := any(id).(type) {
-	case uuid.UUID:
-		nUUID.UUID = v
-		nUUID.Valid = true
-	case *uuid.UUID:
-		if v != nil {
-			nUUID.UUID = *v
-			nUUID.Valid = true
-		}
-	}
-
-	return nUUID
+	return ToNullUUID(nullOf[uuid.UUID](id))
 }
 
 // UnwrapUUID unwraps the sql null UUID to a uuid.UUID struct.
 // If the value is null the function will return an empty uuid.UUID struct.
 // Use UnwrapUUIDPointer() if you want a pointer value.
 func UnwrapUUID(id uuid.NullUUID) uuid.UUID {
-	if !id.Valid {
-		return uuid.Nil
-	}
-
-	return id.UUID
+	return UnwrapUUIDOrZero(id)
 }
 
 // UnwrapUUIDPtr unwraps the sql null UUID to a uuid.UUID pointer.
 func UnwrapUUIDPtr(id uuid.NullUUID) *uuid.UUID {
-	if !id.Valid {
-		return nil
-	}
+	return Unwrap(FromNullUUID(id))
+}
+
+// UnwrapUUIDOr unwraps the sql null UUID to a uuid.UUID struct.
+// If the value is null the function will return the fallback value.
+func UnwrapUUIDOr(id uuid.NullUUID, fallback uuid.UUID) uuid.UUID {
+	return UnwrapOr(FromNullUUID(id), fallback)
+}
 
-	return &id.UUID
+// UnwrapUUIDOrZero unwraps the sql null UUID to a uuid.UUID struct.
+// If the value is null the function will return uuid.Nil.
+func UnwrapUUIDOrZero(id uuid.NullUUID) uuid.UUID {
+	return UnwrapOrZero(FromNullUUID(id))
 }
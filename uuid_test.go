@@ -154,3 +154,43 @@ func TestUnwrapUUIDPtr(t *testing.T) {
 		})
 	}
 }
+
+func TestUnwrapUUIDOr(t *testing.T) {
+	fallback := uuid.New()
+
+	t.Run("should return value of valid uuid.NullUUID", func(t *testing.T) {
+		id := uuid.New()
+
+		if result := UnwrapUUIDOr(uuid.NullUUID{UUID: id, Valid: true}, fallback); result != id {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, id)
+		}
+	})
+
+	t.Run("should return fallback of invalid uuid.NullUUID with value", func(t *testing.T) {
+		if result := UnwrapUUIDOr(uuid.NullUUID{UUID: uuid.New()}, fallback); result != fallback {
+			t.Fatalf("result mismatch got '%v', expected: '%v'", result, fallback)
+		}
+	})
+
+	t.Run("should return uuid.Nil of invalid uuid.NullUUID with value", func(t *testing.T) {
+		if result := UnwrapUUIDOrZero(uuid.NullUUID{UUID: uuid.New()}); result != uuid.Nil {
+			t.Fatalf("result should be zero value, got: '%v'", result)
+		}
+	})
+}
+
+func TestNullUUIDValid(t *testing.T) {
+	var m Mapper
+
+	t.Run("should be valid for non-nil uuid.Nil", func(t *testing.T) {
+		if result := NullUUID(&uuid.Nil); !result.Valid {
+			t.Fatalf("result should be valid, got: '%v'", result)
+		}
+	})
+
+	t.Run("should be valid for non-nil uuid.Nil with the default Mapper", func(t *testing.T) {
+		if result := m.NullUUID(&uuid.Nil); !result.Valid {
+			t.Fatalf("result should be valid, got: '%v'", result)
+		}
+	})
+}